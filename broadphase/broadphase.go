@@ -0,0 +1,39 @@
+// Package broadphase has spatial indexes for quickly finding which of many Rects overlap.
+// Each Rect is stored under an ID chosen by the caller, such as its index in a slice of
+// game objects, and queries return the IDs of Rects that collide according to
+// geo.Rect.CollideRect and geo.Rect.CollidePoint.
+package broadphase
+
+import "github.com/Bredgren/gogame/geo"
+
+// Pair is two IDs whose Rects overlap. A is always less than B.
+type Pair struct {
+	A, B int
+}
+
+func makePair(a, b int) Pair {
+	if a > b {
+		a, b = b, a
+	}
+	return Pair{A: a, B: b}
+}
+
+// Index is the interface shared by the spatial indexes in this package.
+type Index interface {
+	// Insert adds r under the given ID. If the ID already exists it is updated instead.
+	Insert(id int, r geo.Rect)
+	// Update changes the Rect for the given ID. If the ID doesn't exist it is inserted.
+	Update(id int, r geo.Rect)
+	// Remove removes the given ID. Removing an ID that doesn't exist does nothing.
+	Remove(id int)
+	// Rect returns the Rect stored for the given ID.
+	Rect(id int) (r geo.Rect, ok bool)
+	// Len returns the number of IDs stored.
+	Len() int
+	// QueryRect returns the IDs of all Rects that collide with r, in no particular order.
+	QueryRect(r geo.Rect) []int
+	// QueryPoint returns the IDs of all Rects that contain the point, in no particular order.
+	QueryPoint(x, y float64) []int
+	// Pairs returns every pair of IDs whose Rects collide, in no particular order.
+	Pairs() []Pair
+}
@@ -0,0 +1,254 @@
+package broadphase
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+var worldBounds = geo.Rect{X: 0, Y: 0, W: 1000, H: 1000}
+
+func randRects(rng *rand.Rand, n int, maxSize float64) []geo.Rect {
+	rects := make([]geo.Rect, n)
+	for i := range rects {
+		rects[i] = geo.Rect{
+			X: rng.Float64()*(worldBounds.W+100) - 50,
+			Y: rng.Float64()*(worldBounds.H+100) - 50,
+			W: rng.Float64()*maxSize + 1,
+			H: rng.Float64()*maxSize + 1,
+		}
+	}
+	return rects
+}
+
+func bruteForcePairs(rects []geo.Rect, removed map[int]bool) []Pair {
+	pairs := []Pair{}
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			if !removed[i] && !removed[j] && rects[i].CollideRect(rects[j]) {
+				pairs = append(pairs, Pair{A: i, B: j})
+			}
+		}
+	}
+	return pairs
+}
+
+func sortedIDs(ids []int) []int {
+	sort.Ints(ids)
+	return ids
+}
+
+func sortedPairs(pairs []Pair) []Pair {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+func idsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func pairsEqual(a, b []Pair) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testIndex(t *testing.T, name string, index Index) {
+	rng := rand.New(rand.NewSource(1))
+	rects := randRects(rng, 500, 60)
+	for i, r := range rects {
+		index.Insert(i, r)
+	}
+
+	// Move some, remove some.
+	removed := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		id := rng.Intn(len(rects))
+		rects[id].Move(rng.Float64()*100-50, rng.Float64()*100-50)
+		index.Update(id, rects[id])
+	}
+	for i := 0; i < 50; i++ {
+		id := rng.Intn(len(rects))
+		removed[id] = true
+		index.Remove(id)
+	}
+	if index.Len() != len(rects)-len(removed) {
+		t.Errorf("%s: Len got %d, want %d", name, index.Len(), len(rects)-len(removed))
+	}
+	for id := range rects {
+		r, ok := index.Rect(id)
+		if ok == removed[id] || (ok && r != rects[id]) {
+			t.Errorf("%s: Rect(%d) got %#v %v", name, id, r, ok)
+		}
+	}
+
+	for i := 0; i < 50; i++ {
+		query := randRects(rng, 1, 200)[0]
+		want := []int{}
+		for _, id := range query.CollideListAll(rects) {
+			if !removed[id] {
+				want = append(want, id)
+			}
+		}
+		if got := sortedIDs(index.QueryRect(query)); !idsEqual(got, want) {
+			t.Errorf("%s: QueryRect(%#v) got %v, want %v", name, query, got, want)
+		}
+
+		x, y := query.X, query.Y
+		want = []int{}
+		for id, r := range rects {
+			if !removed[id] && r.CollidePoint(x, y) {
+				want = append(want, id)
+			}
+		}
+		if got := sortedIDs(index.QueryPoint(x, y)); !idsEqual(got, want) {
+			t.Errorf("%s: QueryPoint(%v, %v) got %v, want %v", name, x, y, got, want)
+		}
+	}
+
+	want := bruteForcePairs(rects, removed)
+	if got := sortedPairs(index.Pairs()); !pairsEqual(got, want) {
+		t.Errorf("%s: Pairs got %d pairs, want %d", name, len(got), len(want))
+	}
+}
+
+func TestSpatialHash(t *testing.T) {
+	testIndex(t, "SpatialHash", NewSpatialHash(50))
+}
+
+func TestQuadtree(t *testing.T) {
+	testIndex(t, "Quadtree", NewQuadtree(worldBounds, 6))
+}
+
+func TestQuadtreePrune(t *testing.T) {
+	q := NewQuadtree(worldBounds, 6)
+	q.Insert(1, geo.Rect{X: 1, Y: 1, W: 1, H: 1})
+	if q.root.children == [4]*quadNode{} {
+		t.Fatalf("small rect should be stored below the root")
+	}
+	q.Remove(1)
+	if q.root.children != [4]*quadNode{} {
+		t.Errorf("empty nodes were not pruned")
+	}
+}
+
+const benchCount = 2000
+
+func benchRects() []geo.Rect {
+	return randRects(rand.New(rand.NewSource(1)), benchCount, 20)
+}
+
+func BenchmarkBruteForceQuery(b *testing.B) {
+	rects := benchRects()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rects[i%benchCount].CollideListAll(rects)
+	}
+}
+
+func BenchmarkSpatialHashQuery(b *testing.B) {
+	rects := benchRects()
+	h := NewSpatialHash(40)
+	for i, r := range rects {
+		h.Insert(i, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.QueryRect(rects[i%benchCount])
+	}
+}
+
+func BenchmarkQuadtreeQuery(b *testing.B) {
+	rects := benchRects()
+	q := NewQuadtree(worldBounds, 8)
+	for i, r := range rects {
+		q.Insert(i, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.QueryRect(rects[i%benchCount])
+	}
+}
+
+func BenchmarkBruteForcePairs(b *testing.B) {
+	rects := benchRects()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r := range rects {
+			r.CollideListAll(rects)
+		}
+	}
+}
+
+func BenchmarkSpatialHashPairs(b *testing.B) {
+	rects := benchRects()
+	h := NewSpatialHash(40)
+	for i, r := range rects {
+		h.Insert(i, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Pairs()
+	}
+}
+
+func BenchmarkQuadtreePairs(b *testing.B) {
+	rects := benchRects()
+	q := NewQuadtree(worldBounds, 8)
+	for i, r := range rects {
+		q.Insert(i, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Pairs()
+	}
+}
+
+func BenchmarkSpatialHashUpdate(b *testing.B) {
+	rects := benchRects()
+	h := NewSpatialHash(40)
+	for i, r := range rects {
+		h.Insert(i, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id := i % benchCount
+		rects[id].Move(1, 1)
+		h.Update(id, rects[id])
+	}
+}
+
+func BenchmarkQuadtreeUpdate(b *testing.B) {
+	rects := benchRects()
+	q := NewQuadtree(worldBounds, 8)
+	for i, r := range rects {
+		q.Insert(i, r)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		id := i % benchCount
+		rects[id].Move(1, 1)
+		q.Update(id, rects[id])
+	}
+}
@@ -0,0 +1,197 @@
+package broadphase
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+type cell struct {
+	x, y int
+}
+
+type hashItem struct {
+	id       int
+	rect     geo.Rect
+	min, max cell
+	// stamp is used to avoid visiting the same item twice in one query.
+	stamp int
+}
+
+// SpatialHash divides space into a uniform grid of square cells and stores each Rect in
+// every cell it touches. It works best when most Rects are around the size of a cell or
+// smaller. It has no bounds so Rects may be anywhere. Use NewSpatialHash to create one.
+type SpatialHash struct {
+	cellSize float64
+	cells    map[cell][]*hashItem
+	items    map[int]*hashItem
+	stamp    int
+}
+
+var _ Index = (*SpatialHash)(nil)
+
+// NewSpatialHash creates an empty SpatialHash with the given cell size, which must be
+// positive.
+func NewSpatialHash(cellSize float64) *SpatialHash {
+	return &SpatialHash{
+		cellSize: cellSize,
+		cells:    map[cell][]*hashItem{},
+		items:    map[int]*hashItem{},
+	}
+}
+
+// CellSize returns the width and height of each cell.
+func (h *SpatialHash) CellSize() float64 {
+	return h.cellSize
+}
+
+func (h *SpatialHash) cellAt(x, y float64) cell {
+	return cell{x: int(math.Floor(x / h.cellSize)), y: int(math.Floor(y / h.cellSize))}
+}
+
+func (h *SpatialHash) cellRange(r geo.Rect) (min, max cell) {
+	return h.cellAt(r.Left(), r.Top()), h.cellAt(r.Right(), r.Bottom())
+}
+
+// Insert adds r under the given ID. If the ID already exists it is updated instead.
+func (h *SpatialHash) Insert(id int, r geo.Rect) {
+	if _, ok := h.items[id]; ok {
+		h.Update(id, r)
+		return
+	}
+	item := &hashItem{id: id, rect: r}
+	item.min, item.max = h.cellRange(r)
+	h.items[id] = item
+	h.addToCells(item)
+}
+
+// Update changes the Rect for the given ID. If the ID doesn't exist it is inserted.
+func (h *SpatialHash) Update(id int, r geo.Rect) {
+	item, ok := h.items[id]
+	if !ok {
+		h.Insert(id, r)
+		return
+	}
+	item.rect = r
+	min, max := h.cellRange(r)
+	if min == item.min && max == item.max {
+		return
+	}
+	h.removeFromCells(item)
+	item.min, item.max = min, max
+	h.addToCells(item)
+}
+
+// Remove removes the given ID. Removing an ID that doesn't exist does nothing.
+func (h *SpatialHash) Remove(id int) {
+	item, ok := h.items[id]
+	if !ok {
+		return
+	}
+	h.removeFromCells(item)
+	delete(h.items, id)
+}
+
+// Clear removes all IDs.
+func (h *SpatialHash) Clear() {
+	h.cells = map[cell][]*hashItem{}
+	h.items = map[int]*hashItem{}
+}
+
+// Rect returns the Rect stored for the given ID.
+func (h *SpatialHash) Rect(id int) (r geo.Rect, ok bool) {
+	item, ok := h.items[id]
+	if !ok {
+		return geo.Rect{}, false
+	}
+	return item.rect, true
+}
+
+// Len returns the number of IDs stored.
+func (h *SpatialHash) Len() int {
+	return len(h.items)
+}
+
+func (h *SpatialHash) addToCells(item *hashItem) {
+	for x := item.min.x; x <= item.max.x; x++ {
+		for y := item.min.y; y <= item.max.y; y++ {
+			c := cell{x: x, y: y}
+			h.cells[c] = append(h.cells[c], item)
+		}
+	}
+}
+
+func (h *SpatialHash) removeFromCells(item *hashItem) {
+	for x := item.min.x; x <= item.max.x; x++ {
+		for y := item.min.y; y <= item.max.y; y++ {
+			c := cell{x: x, y: y}
+			list := h.cells[c]
+			for i := range list {
+				if list[i] == item {
+					list[i] = list[len(list)-1]
+					list[len(list)-1] = nil
+					list = list[:len(list)-1]
+					break
+				}
+			}
+			if len(list) == 0 {
+				delete(h.cells, c)
+			} else {
+				h.cells[c] = list
+			}
+		}
+	}
+}
+
+// QueryRect returns the IDs of all Rects that collide with r, in no particular order.
+func (h *SpatialHash) QueryRect(r geo.Rect) []int {
+	h.stamp++
+	ids := []int{}
+	min, max := h.cellRange(r)
+	for x := min.x; x <= max.x; x++ {
+		for y := min.y; y <= max.y; y++ {
+			for _, item := range h.cells[cell{x: x, y: y}] {
+				if item.stamp == h.stamp {
+					continue
+				}
+				item.stamp = h.stamp
+				if item.rect.CollideRect(r) {
+					ids = append(ids, item.id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// QueryPoint returns the IDs of all Rects that contain the point, in no particular order.
+func (h *SpatialHash) QueryPoint(x, y float64) []int {
+	ids := []int{}
+	for _, item := range h.cells[h.cellAt(x, y)] {
+		if item.rect.CollidePoint(x, y) {
+			ids = append(ids, item.id)
+		}
+	}
+	return ids
+}
+
+// Pairs returns every pair of IDs whose Rects collide, in no particular order.
+func (h *SpatialHash) Pairs() []Pair {
+	pairs := []Pair{}
+	for c, list := range h.cells {
+		for i, a := range list {
+			for _, b := range list[i+1:] {
+				if !a.rect.CollideRect(b.rect) {
+					continue
+				}
+				// A pair may share many cells, only report it from the one containing the top
+				// left of their intersection.
+				in := a.rect.Intersect(b.rect)
+				if h.cellAt(in.X, in.Y) == c {
+					pairs = append(pairs, makePair(a.id, b.id))
+				}
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,222 @@
+package broadphase
+
+import "github.com/Bredgren/gogame/geo"
+
+type quadItem struct {
+	id   int
+	rect geo.Rect
+	node *quadNode
+}
+
+type quadNode struct {
+	bounds   geo.Rect
+	loose    geo.Rect
+	parent   *quadNode
+	children [4]*quadNode
+	items    []*quadItem
+	depth    int
+}
+
+func newQuadNode(bounds geo.Rect, parent *quadNode, depth int) *quadNode {
+	return &quadNode{
+		bounds: bounds,
+		loose:  bounds.Inflated(bounds.W, bounds.H),
+		parent: parent,
+		depth:  depth,
+	}
+}
+
+// Quadtree is a loose quadtree. Each node's region is twice the size of its strict region
+// so every Rect can be stored in a single node chosen by its size and center, which makes
+// updates cheap. It works well when Rects vary a lot in size. Rects outside of the bounds
+// given to NewQuadtree are still found but are all kept in the root node, so the bounds
+// should cover most of the space in use.
+type Quadtree struct {
+	root     *quadNode
+	items    map[int]*quadItem
+	maxDepth int
+}
+
+var _ Index = (*Quadtree)(nil)
+
+// NewQuadtree creates an empty Quadtree covering bounds that will subdivide at most
+// maxDepth times.
+func NewQuadtree(bounds geo.Rect, maxDepth int) *Quadtree {
+	return &Quadtree{
+		root:     newQuadNode(bounds, nil, 0),
+		items:    map[int]*quadItem{},
+		maxDepth: maxDepth,
+	}
+}
+
+// Bounds returns the region covered by the Quadtree.
+func (q *Quadtree) Bounds() geo.Rect {
+	return q.root.bounds
+}
+
+// Insert adds r under the given ID. If the ID already exists it is updated instead.
+func (q *Quadtree) Insert(id int, r geo.Rect) {
+	if _, ok := q.items[id]; ok {
+		q.Update(id, r)
+		return
+	}
+	item := &quadItem{id: id, rect: r}
+	q.items[id] = item
+	q.insert(item)
+}
+
+func (q *Quadtree) insert(item *quadItem) {
+	node := q.root
+	cx, cy := item.rect.Center()
+	if node.bounds.CollidePoint(cx, cy) {
+		for node.depth < q.maxDepth && item.rect.W <= node.bounds.W/2 && item.rect.H <= node.bounds.H/2 {
+			i := 0
+			if cx >= node.bounds.CenterX() {
+				i |= 1
+			}
+			if cy >= node.bounds.CenterY() {
+				i |= 2
+			}
+			if node.children[i] == nil {
+				b := geo.Rect{X: node.bounds.X, Y: node.bounds.Y, W: node.bounds.W / 2, H: node.bounds.H / 2}
+				b.Move(float64(i&1)*b.W, float64(i>>1)*b.H)
+				node.children[i] = newQuadNode(b, node, node.depth+1)
+			}
+			node = node.children[i]
+		}
+	}
+	item.node = node
+	node.items = append(node.items, item)
+}
+
+// Update changes the Rect for the given ID. If the ID doesn't exist it is inserted.
+func (q *Quadtree) Update(id int, r geo.Rect) {
+	item, ok := q.items[id]
+	if !ok {
+		q.Insert(id, r)
+		return
+	}
+	item.rect = r
+	// Only move it if it no longer fits or could go deeper.
+	node := item.node
+	cx, cy := r.Center()
+	if node != q.root && node.bounds.CollidePoint(cx, cy) && r.W <= node.bounds.W && r.H <= node.bounds.H &&
+		(node.depth == q.maxDepth || r.W > node.bounds.W/2 || r.H > node.bounds.H/2) {
+		return
+	}
+	q.removeFromNode(item)
+	q.insert(item)
+}
+
+// Remove removes the given ID. Removing an ID that doesn't exist does nothing.
+func (q *Quadtree) Remove(id int) {
+	item, ok := q.items[id]
+	if !ok {
+		return
+	}
+	q.removeFromNode(item)
+	delete(q.items, id)
+}
+
+// Clear removes all IDs.
+func (q *Quadtree) Clear() {
+	q.root = newQuadNode(q.root.bounds, nil, 0)
+	q.items = map[int]*quadItem{}
+}
+
+func (q *Quadtree) removeFromNode(item *quadItem) {
+	node := item.node
+	for i := range node.items {
+		if node.items[i] == item {
+			node.items[i] = node.items[len(node.items)-1]
+			node.items[len(node.items)-1] = nil
+			node.items = node.items[:len(node.items)-1]
+			break
+		}
+	}
+	item.node = nil
+
+	// Prune empty leaves so the tree doesn't keep growing as things move around.
+	for node.parent != nil && len(node.items) == 0 && node.children == [4]*quadNode{} {
+		parent := node.parent
+		for i := range parent.children {
+			if parent.children[i] == node {
+				parent.children[i] = nil
+			}
+		}
+		node = parent
+	}
+}
+
+// Rect returns the Rect stored for the given ID.
+func (q *Quadtree) Rect(id int) (r geo.Rect, ok bool) {
+	item, ok := q.items[id]
+	if !ok {
+		return geo.Rect{}, false
+	}
+	return item.rect, true
+}
+
+// Len returns the number of IDs stored.
+func (q *Quadtree) Len() int {
+	return len(q.items)
+}
+
+// QueryRect returns the IDs of all Rects that collide with r, in no particular order.
+func (q *Quadtree) QueryRect(r geo.Rect) []int {
+	ids := []int{}
+	q.query(q.root, func(loose geo.Rect) bool {
+		return loose.CollideRect(r)
+	}, func(item *quadItem) {
+		if item.rect.CollideRect(r) {
+			ids = append(ids, item.id)
+		}
+	})
+	return ids
+}
+
+// QueryPoint returns the IDs of all Rects that contain the point, in no particular order.
+func (q *Quadtree) QueryPoint(x, y float64) []int {
+	ids := []int{}
+	q.query(q.root, func(loose geo.Rect) bool {
+		return loose.CollidePoint(x, y)
+	}, func(item *quadItem) {
+		if item.rect.CollidePoint(x, y) {
+			ids = append(ids, item.id)
+		}
+	})
+	return ids
+}
+
+// query calls visit for every item in node and each descendant whose loose bounds pass
+// the test. The root is always visited since it can hold items outside of its bounds.
+func (q *Quadtree) query(node *quadNode, test func(loose geo.Rect) bool, visit func(*quadItem)) {
+	if node != q.root && !test(node.loose) {
+		return
+	}
+	for _, item := range node.items {
+		visit(item)
+	}
+	for _, child := range node.children {
+		if child != nil {
+			q.query(child, test, visit)
+		}
+	}
+}
+
+// Pairs returns every pair of IDs whose Rects collide, in no particular order.
+func (q *Quadtree) Pairs() []Pair {
+	pairs := []Pair{}
+	for _, a := range q.items {
+		// Loose regions overlap, so neighboring branches need to be searched too. Each pair
+		// is only reported from the item with the lower ID.
+		q.query(q.root, func(loose geo.Rect) bool {
+			return loose.CollideRect(a.rect)
+		}, func(b *quadItem) {
+			if a.id < b.id && a.rect.CollideRect(b.rect) {
+				pairs = append(pairs, Pair{A: a.id, B: b.id})
+			}
+		})
+	}
+	return pairs
+}
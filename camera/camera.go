@@ -0,0 +1,199 @@
+// Package camera maps between world coordinates, where the game happens, and screen
+// coordinates, where it is drawn. A Camera can follow a target, stay inside the level and
+// shake.
+//
+// A typical frame might look like:
+//
+//	cam.Update(dt)
+//	display.Save()
+//	cam.Apply(display)
+//	// Draw the world using world coordinates.
+//	display.Restore()
+//	// Draw the HUD using screen coordinates.
+//	...
+//	cursor := cam.ScreenToWorld(ggweb.MousePos())
+package camera
+
+import (
+	"math"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+	"github.com/Bredgren/gogame/noise"
+)
+
+// Transformer is anything whose drawing can be transformed, such as a *ggweb.Surface.
+type Transformer interface {
+	Transform(t geo.Transform)
+}
+
+// Camera looks at the world through a rectangle on the screen. Use New to create one.
+type Camera struct {
+	// Pos is the point in the world that appears at the center of Screen.
+	Pos geo.Vec
+	// Zoom is how many pixels one world unit takes up. Larger values zoom in.
+	Zoom float64
+	// Rotation is the angle of the camera in radians, counterclockwise in screen
+	// coordinates. The world appears rotated the opposite way.
+	Rotation float64
+	// Screen is where the Camera draws to in screen coordinates, usually the Rect of the
+	// Surface being drawn to.
+	Screen geo.Rect
+
+	// Bounds is the area of the world the Camera stays inside, if it isn't empty. If the
+	// view is larger than Bounds then Bounds is kept centered.
+	Bounds geo.Rect
+
+	// Target, if not nil, is followed by Update.
+	Target geo.VecGen
+	// Deadzone is an area in screen coordinates that Target can move around in without the
+	// Camera moving. If it is empty then the Camera tries to keep Target at the center.
+	Deadzone geo.Rect
+	// Smoothing is the fraction of the distance to Target left after following it for one
+	// second, between 0 and 1. At 0 the Camera keeps up exactly.
+	Smoothing float64
+
+	// Trauma is how much the Camera is shaking, between 0 and 1. The amount of shake grows
+	// with the square of Trauma, so small knocks are subtle and large ones are violent. It
+	// is usually added to with AddTrauma.
+	Trauma float64
+	// TraumaDecay is how much Trauma decreases per second.
+	TraumaDecay float64
+	// MaxShakeOffset is the furthest the view moves, in pixels, at full Trauma.
+	MaxShakeOffset float64
+	// MaxShakeAngle is the furthest the view rotates, in radians, at full Trauma.
+	MaxShakeAngle float64
+	// ShakeFrequency is roughly how many times per second the shake changes direction.
+	ShakeFrequency float64
+
+	noise     *noise.Noise
+	shakeTime float64
+}
+
+// New creates a Camera that draws to screen and starts out looking at the same area of the
+// world, so that world and screen coordinates are the same.
+func New(screen geo.Rect) *Camera {
+	x, y := screen.Center()
+	return &Camera{
+		Pos:            geo.Vec{X: x, Y: y},
+		Zoom:           1,
+		Screen:         screen,
+		TraumaDecay:    1,
+		MaxShakeOffset: 16,
+		MaxShakeAngle:  0.1,
+		ShakeFrequency: 15,
+		noise:          noise.New(0),
+	}
+}
+
+// Transform returns the Transform from world coordinates to screen coordinates, including
+// any shake.
+func (c *Camera) Transform() geo.Transform {
+	return c.transform(true)
+}
+
+func (c *Camera) transform(shake bool) geo.Transform {
+	x, y := c.Screen.Center()
+	rot := c.Rotation
+	if s := c.Trauma * c.Trauma; shake && s > 0 {
+		t := c.shakeTime * c.ShakeFrequency
+		x += c.MaxShakeOffset * s * c.noise.Perlin2(t, 0.5)
+		y += c.MaxShakeOffset * s * c.noise.Perlin2(t, 10.5)
+		rot += c.MaxShakeAngle * s * c.noise.Perlin2(t, 20.5)
+	}
+	return geo.TranslateTransform(x, y).Rotated(-rot).Scaled(c.Zoom, c.Zoom).Translated(-c.Pos.X, -c.Pos.Y)
+}
+
+// Apply transforms t so that drawing to it in world coordinates appears in the right place
+// on the screen. It should usually be between a Save and Restore.
+func (c *Camera) Apply(t Transformer) {
+	t.Transform(c.Transform())
+}
+
+// WorldToScreen returns the screen position of the world position v.
+func (c *Camera) WorldToScreen(v geo.Vec) geo.Vec {
+	return c.Transform().Apply(v)
+}
+
+// ScreenToWorld returns the world position at the screen position v.
+func (c *Camera) ScreenToWorld(v geo.Vec) geo.Vec {
+	inv, _ := c.Transform().Inverse()
+	return inv.Apply(v)
+}
+
+// WorldToScreenRect returns the smallest Rect in screen coordinates that contains the world
+// Rect r.
+func (c *Camera) WorldToScreenRect(r geo.Rect) geo.Rect {
+	return c.Transform().ApplyRect(r)
+}
+
+// ScreenToWorldRect returns the smallest Rect in world coordinates that contains the screen
+// Rect r.
+func (c *Camera) ScreenToWorldRect(r geo.Rect) geo.Rect {
+	inv, _ := c.Transform().Inverse()
+	return inv.ApplyRect(r)
+}
+
+// View returns the smallest Rect in world coordinates that contains everything visible,
+// which can be used to skip drawing things that are off screen.
+func (c *Camera) View() geo.Rect {
+	return c.ScreenToWorldRect(c.Screen)
+}
+
+// AddTrauma increases Trauma by amount, up to 1.
+func (c *Camera) AddTrauma(amount float64) {
+	c.Trauma = math.Min(c.Trauma+amount, 1)
+}
+
+// Update follows Target, clamps to Bounds and advances the shake by dt.
+func (c *Camera) Update(dt time.Duration) {
+	sec := dt.Seconds()
+	if c.Target != nil {
+		c.follow(c.Target(), sec)
+	}
+	c.Clamp()
+	c.shakeTime += sec
+	c.Trauma = math.Max(c.Trauma-c.TraumaDecay*sec, 0)
+}
+
+func (c *Camera) follow(target geo.Vec, sec float64) {
+	want := target
+	if c.Deadzone.W > 0 || c.Deadzone.H > 0 {
+		s := c.transform(false).Apply(target)
+		var d geo.Vec
+		switch {
+		case s.X < c.Deadzone.Left():
+			d.X = s.X - c.Deadzone.Left()
+		case s.X > c.Deadzone.Right():
+			d.X = s.X - c.Deadzone.Right()
+		}
+		switch {
+		case s.Y < c.Deadzone.Top():
+			d.Y = s.Y - c.Deadzone.Top()
+		case s.Y > c.Deadzone.Bottom():
+			d.Y = s.Y - c.Deadzone.Bottom()
+		}
+		want = c.Pos.Plus(d.Rotated(c.Rotation).Times(1 / c.Zoom))
+	}
+	c.Pos.Add(want.Minus(c.Pos).Times(1 - math.Pow(c.Smoothing, sec)))
+}
+
+// Clamp moves Pos so that the view, not including shake, stays inside Bounds. It does
+// nothing if Bounds is empty.
+func (c *Camera) Clamp() {
+	if c.Bounds.W <= 0 || c.Bounds.H <= 0 {
+		return
+	}
+	inv, _ := c.transform(false).Inverse()
+	view := inv.ApplyRect(c.Screen)
+	c.Pos.X = clamp(c.Pos.X, c.Bounds.Left()+view.W/2, c.Bounds.Right()-view.W/2)
+	c.Pos.Y = clamp(c.Pos.Y, c.Bounds.Top()+view.H/2, c.Bounds.Bottom()-view.H/2)
+}
+
+// clamp returns n limited to [min, max], or the middle of them if min is greater.
+func clamp(n, min, max float64) float64 {
+	if min > max {
+		return (min + max) / 2
+	}
+	return math.Max(min, math.Min(n, max))
+}
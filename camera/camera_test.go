@@ -0,0 +1,123 @@
+package camera
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const e = 1e-10
+
+var screen = geo.Rect{W: 200, H: 100}
+
+func TestWorldToScreen(t *testing.T) {
+	cases := []struct {
+		pos         geo.Vec
+		zoom, rot   float64
+		world, want geo.Vec
+		view        geo.Rect
+	}{
+		{geo.Vec{X: 100, Y: 50}, 1, 0, geo.Vec{X: 3, Y: 4}, geo.Vec{X: 3, Y: 4}, screen},
+		{geo.Vec{X: 0, Y: 0}, 1, 0, geo.Vec{X: 3, Y: 4}, geo.Vec{X: 103, Y: 54},
+			geo.Rect{X: -100, Y: -50, W: 200, H: 100}},
+		{geo.Vec{X: 0, Y: 0}, 2, 0, geo.Vec{X: 10, Y: -5}, geo.Vec{X: 120, Y: 40},
+			geo.Rect{X: -50, Y: -25, W: 100, H: 50}},
+		// Turning the camera left makes the world appear to turn right.
+		{geo.Vec{X: 0, Y: 0}, 1, math.Pi / 2, geo.Vec{X: 10, Y: 0}, geo.Vec{X: 100, Y: 60},
+			geo.Rect{X: -50, Y: -100, W: 100, H: 200}},
+		{geo.Vec{X: 10, Y: 10}, 0.5, math.Pi, geo.Vec{X: 20, Y: 10}, geo.Vec{X: 95, Y: 50},
+			geo.Rect{X: -190, Y: -90, W: 400, H: 200}},
+	}
+
+	for i, c := range cases {
+		cam := New(screen)
+		cam.Pos, cam.Zoom, cam.Rotation = c.pos, c.zoom, c.rot
+		if got := cam.WorldToScreen(c.world); !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+		if got := cam.ScreenToWorld(c.want); !got.Equals(c.world, e) {
+			t.Errorf("case %d: inverse got %#v, want %#v", i, got, c.world)
+		}
+		if got := cam.View(); !rectEquals(got, c.view) {
+			t.Errorf("case %d: view got %#v, want %#v", i, got, c.view)
+		}
+		if got := cam.WorldToScreenRect(cam.View()); !rectEquals(got, screen) {
+			t.Errorf("case %d: view on screen got %#v, want %#v", i, got, screen)
+		}
+	}
+}
+
+func TestFollow(t *testing.T) {
+	target := geo.Vec{X: 500, Y: 500}
+	cases := []struct {
+		deadzone  geo.Rect
+		smoothing float64
+		bounds    geo.Rect
+		dt        time.Duration
+		want      geo.Vec
+	}{
+		{geo.Rect{}, 0, geo.Rect{}, time.Second / 60, target},
+		{geo.Rect{}, 0.25, geo.Rect{}, time.Second, geo.Vec{X: 400, Y: 387.5}},
+		{geo.Rect{}, 0.25, geo.Rect{}, time.Second / 2, geo.Vec{X: 300, Y: 275}},
+		{geo.Rect{}, 0.25, geo.Rect{}, 0, geo.Vec{X: 100, Y: 50}},
+		// The target ends up at the bottom right corner of the deadzone.
+		{geo.Rect{X: 50, Y: 25, W: 100, H: 50}, 0, geo.Rect{}, time.Second, geo.Vec{X: 450, Y: 475}},
+		{geo.Rect{}, 0, geo.Rect{W: 300, H: 1000}, time.Second, geo.Vec{X: 200, Y: 500}},
+		{geo.Rect{}, 0, geo.Rect{W: 100, H: 80}, time.Second, geo.Vec{X: 50, Y: 40}},
+	}
+
+	for i, c := range cases {
+		cam := New(screen)
+		cam.Target = geo.StaticVec(target)
+		cam.Deadzone, cam.Smoothing, cam.Bounds = c.deadzone, c.smoothing, c.bounds
+		cam.Update(c.dt)
+		if !cam.Pos.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, cam.Pos, c.want)
+		}
+	}
+
+	// Within the deadzone nothing moves.
+	cam := New(screen)
+	cam.Target = geo.StaticVec(geo.Vec{X: 120, Y: 60})
+	cam.Deadzone = geo.Rect{X: 50, Y: 25, W: 100, H: 50}
+	cam.Update(time.Second)
+	if want := (geo.Vec{X: 100, Y: 50}); cam.Pos != want {
+		t.Errorf("got %#v, want %#v", cam.Pos, want)
+	}
+}
+
+func TestShake(t *testing.T) {
+	cam := New(screen)
+	still := cam.Transform()
+	cam.AddTrauma(0.7)
+	cam.AddTrauma(0.7)
+	if cam.Trauma != 1 {
+		t.Errorf("got trauma %v, want 1", cam.Trauma)
+	}
+
+	moved := 0
+	for i := 0; i < 10; i++ {
+		cam.Update(time.Second / 20)
+		if !cam.Transform().Equals(still, 1e-3) {
+			moved++
+		}
+		p := cam.WorldToScreen(cam.Pos)
+		if d := p.Dist(geo.Vec{X: 100, Y: 50}); d > math.Sqrt2*cam.MaxShakeOffset {
+			t.Errorf("shook %v pixels", d)
+		}
+	}
+	if moved < 8 {
+		t.Errorf("only moved %d times", moved)
+	}
+
+	cam.Update(time.Second)
+	if cam.Trauma != 0 || cam.Transform() != still {
+		t.Errorf("still shaking with trauma %v", cam.Trauma)
+	}
+}
+
+func rectEquals(a, b geo.Rect) bool {
+	return math.Abs(a.X-b.X) < e && math.Abs(a.Y-b.Y) < e && math.Abs(a.W-b.W) < e && math.Abs(a.H-b.H) < e
+}
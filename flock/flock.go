@@ -0,0 +1,165 @@
+// Package flock moves large groups of particles together like schools of fish or flocks of
+// birds using Craig Reynolds' boids rules. Each boid steers away from neighbors that are
+// too close (separation), towards the average heading of its neighbors (alignment) and
+// towards their average position (cohesion). Neighbors are found with a
+// broadphase.SpatialHash so thousands of boids stay fast.
+//
+// The boids are plain particle.Particles, so a particle.System can be a flock:
+//
+//	birds := flock.NewFlock(50, 100, 200)
+//	...
+//	birds.SteerSystem(system)
+//	system.Update(dt)
+package flock
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/broadphase"
+	"github.com/Bredgren/gogame/geo"
+	"github.com/Bredgren/gogame/particle"
+)
+
+// Flock holds the settings shared by a group of boids. Use NewFlock to create one.
+type Flock struct {
+	// Radius is how far away a boid can see its neighbors.
+	Radius float64
+	// SeparationRadius is how close a neighbor has to be before a boid steers away from
+	// it. It should be less than Radius or the boids will just scatter.
+	SeparationRadius float64
+	// FieldOfView is the angle, in radians, centered on a boid's velocity within which it can
+	// see its neighbors. A value of 2π or more, or a boid that isn't moving, sees all around.
+	FieldOfView float64
+	// Separation, Alignment and Cohesion are the weights of each rule. Setting one to 0
+	// turns it off.
+	Separation, Alignment, Cohesion float64
+	// MaxSpeed is the speed each boid tries to fly at. MaxForce is the strongest steering
+	// force each rule and the total can apply.
+	MaxSpeed, MaxForce float64
+
+	hash *broadphase.SpatialHash
+}
+
+// NewFlock creates a Flock where boids see radius all around except for a quarter circle
+// behind them, and keep half of radius apart. Separation has 1.5 times the weight of
+// alignment and cohesion.
+func NewFlock(radius, maxSpeed, maxForce float64) *Flock {
+	return &Flock{
+		Radius:           radius,
+		SeparationRadius: radius / 2,
+		FieldOfView:      1.5 * math.Pi,
+		Separation:       1.5,
+		Alignment:        1,
+		Cohesion:         1,
+		MaxSpeed:         maxSpeed,
+		MaxForce:         maxForce,
+	}
+}
+
+// index puts each boid in the spatial hash under its index in boids.
+func (f *Flock) index(boids []*particle.Particle) {
+	if f.hash == nil || f.hash.CellSize() != f.Radius {
+		f.hash = broadphase.NewSpatialHash(f.Radius)
+	} else {
+		f.hash.Clear()
+	}
+	for i, b := range boids {
+		f.hash.Insert(i, geo.Rect{X: b.Pos.X, Y: b.Pos.Y})
+	}
+}
+
+// neighbors returns the indices of the boids that boids[i] can see. The boids must have
+// been indexed first.
+func (f *Flock) neighbors(boids []*particle.Particle, i int) []int {
+	b := boids[i]
+	seeAll := f.FieldOfView >= 2*math.Pi || b.Vel.Len2() == 0
+	var heading geo.Vec
+	if !seeAll {
+		heading = b.Vel.Normalized()
+	}
+	minCos := math.Cos(f.FieldOfView / 2)
+
+	area := geo.Rect{X: b.Pos.X - f.Radius, Y: b.Pos.Y - f.Radius, W: 2 * f.Radius, H: 2 * f.Radius}
+	ids := f.hash.QueryRect(area)
+	seen := ids[:0]
+	for _, j := range ids {
+		if j == i {
+			continue
+		}
+		offset := boids[j].Pos.Minus(b.Pos)
+		d := offset.Len()
+		if d > f.Radius {
+			continue
+		}
+		if !seeAll && d > 0 && offset.Dot(heading) < d*minCos {
+			continue
+		}
+		seen = append(seen, j)
+	}
+	return seen
+}
+
+// steerTowards returns the force that changes vel to desired at MaxSpeed, limited to
+// MaxForce, or the zero vector if there is no desired direction.
+func (f *Flock) steerTowards(desired, vel geo.Vec) geo.Vec {
+	if desired.Len2() == 0 {
+		return geo.Vec{}
+	}
+	return desired.WithLen(f.MaxSpeed).Minus(vel).Limited(f.MaxForce)
+}
+
+// force returns the combined steering force on boids[i].
+func (f *Flock) force(boids []*particle.Particle, i int) geo.Vec {
+	seen := f.neighbors(boids, i)
+	if len(seen) == 0 {
+		return geo.Vec{}
+	}
+	b := boids[i]
+	var away, vel, center geo.Vec
+	for _, j := range seen {
+		n := boids[j]
+		// Closer neighbors push harder.
+		offset := b.Pos.Minus(n.Pos)
+		if d2 := offset.Len2(); d2 > 0 && d2 < f.SeparationRadius*f.SeparationRadius {
+			away.Add(offset.DividedBy(d2))
+		}
+		vel.Add(n.Vel)
+		center.Add(n.Pos)
+	}
+	center.Mul(1 / float64(len(seen)))
+
+	var sum geo.Vec
+	sum.Add(f.steerTowards(away, b.Vel).Times(f.Separation))
+	sum.Add(f.steerTowards(vel, b.Vel).Times(f.Alignment))
+	sum.Add(f.steerTowards(center.Minus(b.Pos), b.Vel).Times(f.Cohesion))
+	return sum.Limited(f.MaxForce)
+}
+
+// Forces returns the steering force for each of boids without applying them. A boid that
+// can't see any others gets the zero vector so it keeps going the way it was.
+func (f *Flock) Forces(boids []*particle.Particle) []geo.Vec {
+	f.index(boids)
+	forces := make([]geo.Vec, len(boids))
+	for i := range boids {
+		forces[i] = f.force(boids, i)
+	}
+	return forces
+}
+
+// Steer applies the steering force to each of boids. Like Particle.ApplyForce it takes
+// effect on the next Update.
+func (f *Flock) Steer(boids []*particle.Particle) {
+	for i, force := range f.Forces(boids) {
+		boids[i].ApplyForce(force)
+	}
+}
+
+// SteerSystem treats every active particle in s as a boid and applies its steering force.
+// It should be called before s.Update.
+func (f *Flock) SteerSystem(s *particle.System) {
+	boids := []*particle.Particle{}
+	s.ForEachParticle(func(p *particle.SystemParticle) {
+		boids = append(boids, &p.Particle)
+	})
+	f.Steer(boids)
+}
@@ -0,0 +1,139 @@
+package flock
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+	"github.com/Bredgren/gogame/particle"
+)
+
+const e = 1e-10
+
+const frame = time.Second / 60
+
+func boid(x, y, vx, vy float64) *particle.Particle {
+	return &particle.Particle{Pos: geo.Vec{X: x, Y: y}, Vel: geo.Vec{X: vx, Y: vy}, Mass: 1}
+}
+
+func TestRules(t *testing.T) {
+	cases := []struct {
+		sep, align, coh float64
+		boids           []*particle.Particle
+		want            geo.Vec
+	}{
+		// Alone.
+		{1, 1, 1, []*particle.Particle{boid(0, 0, 10, 0)}, geo.Vec{}},
+		// Too far away.
+		{1, 1, 1, []*particle.Particle{boid(0, 0, 10, 0), boid(0, 21, 10, 0)}, geo.Vec{}},
+		// Behind it.
+		{1, 1, 1, []*particle.Particle{boid(0, 0, 10, 0), boid(-10, 1, 0, 10)}, geo.Vec{}},
+		// Not moving so it sees behind.
+		{0, 0, 1, []*particle.Particle{boid(0, 0, 0, 0), boid(-10, 0, 0, 0)}, geo.Vec{X: -5}},
+		{1, 0, 0, []*particle.Particle{boid(0, 0, 10, 0), boid(5, 5, 10, 0)}, geo.Vec{X: -10 - 5*math.Sqrt2, Y: -5 * math.Sqrt2}.WithLen(5)},
+		{0, 1, 0, []*particle.Particle{boid(0, 0, 10, 0), boid(5, 5, 0, 10)}, geo.Vec{X: -10, Y: 10}.WithLen(5)},
+		{0, 1, 0, []*particle.Particle{boid(0, 0, 8, 0), boid(5, 5, 1, 0)}, geo.Vec{X: 2}},
+		{0, 0, 1, []*particle.Particle{boid(0, 0, 10, 0), boid(5, 5, 10, 0), boid(5, -5, 10, 0)}, geo.Vec{}},
+		// The total is limited too.
+		{0, 0, 2, []*particle.Particle{boid(0, 0, 10, 0), boid(5, 5, 10, 0)}, geo.Vec{X: 5*math.Sqrt2 - 10, Y: 5 * math.Sqrt2}.WithLen(5)},
+	}
+
+	for i, c := range cases {
+		f := NewFlock(20, 10, 5)
+		f.Separation, f.Alignment, f.Cohesion = c.sep, c.align, c.coh
+		if got := f.Forces(c.boids)[0]; !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	rng := geo.NewRngSeed(1)
+	pos, vel := rng.RandVecRect(geo.Rect{W: 500, H: 500}), rng.RandVecCircle(0, 10)
+	boids := make([]*particle.Particle, 1000)
+	for i := range boids {
+		boids[i] = &particle.Particle{Pos: pos(), Vel: vel()}
+	}
+	boids[0].Vel = geo.Vec{}
+
+	f := NewFlock(30, 10, 5)
+	f.index(boids)
+	for i, b := range boids {
+		got := f.neighbors(boids, i)
+		sort.Ints(got)
+		want := []int{}
+		for j, n := range boids {
+			offset := n.Pos.Minus(b.Pos)
+			if j == i || offset.Len() > f.Radius {
+				continue
+			}
+			if b.Vel.Len2() > 0 && math.Abs(offset.AngleFrom(b.Vel)) > f.FieldOfView/2 {
+				continue
+			}
+			want = append(want, j)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("boid %d: got %v, want %v", i, got, want)
+		}
+		for k := range got {
+			if got[k] != want[k] {
+				t.Fatalf("boid %d: got %v, want %v", i, got, want)
+			}
+		}
+	}
+}
+
+func TestSteerSystem(t *testing.T) {
+	// A flock's exact path is chaotic, so rather than where a single one ends up check how
+	// well each boid lines up with those around it, taking the median over a few seeds.
+	var orders []float64
+	for seed := int64(1); seed <= 5; seed++ {
+		rng := geo.NewRngSeed(seed)
+		s := particle.NewSystem(200)
+		s.Rate = 200 * 60
+		s.InitLife = time.Hour
+		s.InitPos = rng.RandVecCircle(0, 100)
+		s.InitVel = rng.RandVecCircle(50, 50)
+		s.InitMass = geo.ConstNum(1)
+		s.Update(frame)
+
+		f := NewFlock(50, 50, 100)
+		for i := 0; i < 10*60; i++ {
+			f.SteerSystem(s)
+			s.Update(frame)
+		}
+
+		ps := s.Particles()
+		if len(ps) != 200 {
+			t.Fatalf("seed %d: got %d boids", seed, len(ps))
+		}
+		order := 0.0
+		for _, p := range ps {
+			var vel geo.Vec
+			for _, p2 := range ps {
+				if p2 == p {
+					continue
+				}
+				// They don't bunch up.
+				if d := p.Pos.Dist(p2.Pos); d < 1 {
+					t.Fatalf("seed %d: boids only %v apart", seed, d)
+				} else if d < f.Radius {
+					vel.Add(p2.Vel)
+				}
+			}
+			if vel.Len2() > 0 {
+				order += vel.Normalized().Dot(p.Vel.Normalized())
+			}
+		}
+		orders = append(orders, order/float64(len(ps)))
+	}
+
+	// They start off going every which way and end up flying the same way as their
+	// neighbors.
+	sort.Float64s(orders)
+	if median := orders[len(orders)/2]; median < 0.9 {
+		t.Errorf("got median order %v of %v", median, orders)
+	}
+}
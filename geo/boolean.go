@@ -0,0 +1,272 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// The boolean functions work on regions made of any number of simple Polygons. A point is
+// in a region if it is inside an odd number of its Polygons, so a Polygon inside another
+// is a hole, and the winding of the inputs doesn't matter. The results are regions where
+// outer boundaries are wound clockwise and holes counterclockwise, so they fill correctly
+// with either fill rule and can be split up with GroupHoles. Edges that the two regions
+// share exactly are handled, but the inputs must not intersect themselves.
+
+// PolygonUnion returns the region covered by a, b or both.
+func PolygonUnion(a, b []Polygon) []Polygon {
+	return polygonBoolean(a, b, opUnion)
+}
+
+// PolygonIntersection returns the region covered by both a and b.
+func PolygonIntersection(a, b []Polygon) []Polygon {
+	return polygonBoolean(a, b, opIntersection)
+}
+
+// PolygonDifference returns the region covered by a but not b. For example, to carve a
+// circular explosion out of some terrain:
+//
+//	blast := Circle{X: x, Y: y, R: 30}
+//	terrain = PolygonDifference(terrain, []Polygon{CirclePolygon(blast, 16)})
+func PolygonDifference(a, b []Polygon) []Polygon {
+	return polygonBoolean(a, b, opDifference)
+}
+
+// CirclePolygon returns a regular Polygon with n vertices on the edge of c, wound
+// clockwise. n less than 3 is treated as 3.
+func CirclePolygon(c Circle, n int) Polygon {
+	if n < 3 {
+		n = 3
+	}
+	p := make(Polygon, n)
+	for i := range p {
+		rad := 2 * math.Pi * float64(i) / float64(n)
+		// Rotating clockwise, which is negative radians.
+		p[i] = Vec{X: c.R}.Rotated(-rad).Plus(Vec{X: c.X, Y: c.Y})
+	}
+	return p
+}
+
+type boolOp int
+
+const (
+	opUnion boolOp = iota
+	opIntersection
+	opDifference
+)
+
+type boolEdge struct {
+	a, b Vec
+}
+
+// polygonBoolean splits the edges of both regions where they cross each other, keeps the
+// pieces that border the result and then joins them back up into outlines.
+func polygonBoolean(a, b []Polygon, op boolOp) []Polygon {
+	ra, rb := normalizeRegion(a), normalizeRegion(b)
+	ea, eb := regionEdges(ra), regionEdges(rb)
+	sa, sb := splitEdges(ea, eb)
+
+	shared := make(map[boolEdge]bool, len(sb))
+	for _, e := range sb {
+		shared[e] = true
+	}
+	kept := []boolEdge{}
+	for _, e := range sa {
+		same, opposite := shared[e], shared[boolEdge{e.b, e.a}]
+		inside := !same && !opposite && regionContains(rb, e.a.Plus(e.b).Times(0.5))
+		switch op {
+		case opUnion:
+			if same || !opposite && !inside {
+				kept = append(kept, e)
+			}
+		case opIntersection:
+			if same || inside {
+				kept = append(kept, e)
+			}
+		case opDifference:
+			if opposite || !same && !inside {
+				kept = append(kept, e)
+			}
+		}
+	}
+	shared = make(map[boolEdge]bool, len(sa))
+	for _, e := range sa {
+		shared[e] = true
+	}
+	for _, e := range sb {
+		// Shared edges were already decided above.
+		if shared[e] || shared[boolEdge{e.b, e.a}] {
+			continue
+		}
+		inside := regionContains(ra, e.a.Plus(e.b).Times(0.5))
+		switch op {
+		case opUnion:
+			if !inside {
+				kept = append(kept, e)
+			}
+		case opIntersection:
+			if inside {
+				kept = append(kept, e)
+			}
+		case opDifference:
+			if inside {
+				kept = append(kept, boolEdge{e.b, e.a})
+			}
+		}
+	}
+	return linkEdges(kept)
+}
+
+// normalizeRegion returns a copy of region where outer boundaries are clockwise and holes
+// are counterclockwise.
+func normalizeRegion(region []Polygon) []Polygon {
+	r := make([]Polygon, 0, len(region))
+	for _, p := range region {
+		if p = removeCollinear(p); len(p) >= 3 {
+			r = append(r, p)
+		}
+	}
+	for i, p := range r {
+		depth := 0
+		for j, other := range r {
+			if i != j && other.CollidePoint(p[0].X, p[0].Y) {
+				depth++
+			}
+		}
+		if p.Clockwise() != (depth%2 == 0) {
+			p.Reverse()
+		}
+	}
+	return r
+}
+
+// regionContains returns true if v is inside an odd number of region's Polygons.
+func regionContains(region []Polygon, v Vec) bool {
+	inside := false
+	for _, p := range region {
+		if p.CollidePoint(v.X, v.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func regionEdges(region []Polygon) []boolEdge {
+	edges := []boolEdge{}
+	for _, p := range region {
+		for i := range p {
+			edges = append(edges, boolEdge{p[i], p[(i+1)%len(p)]})
+		}
+	}
+	return edges
+}
+
+// splitEdges splits each edge in ea wherever it touches an edge in eb and vice versa. Both
+// sides of each split use exactly the same point so that the pieces can be matched up.
+func splitEdges(ea, eb []boolEdge) ([]boolEdge, []boolEdge) {
+	const eps = 1e-9
+	splitsA, splitsB := make([][]Vec, len(ea)), make([][]Vec, len(eb))
+	onEdge := func(e boolEdge, v Vec) bool {
+		d := e.b.Minus(e.a)
+		t := v.Minus(e.a).Dot(d) / d.Len2()
+		return t > eps && t < 1-eps && math.Abs(cross(d, v.Minus(e.a))) <= eps*d.Len2()
+	}
+	for i, a := range ea {
+		ba := Segment{A: a.a, B: a.b}.Bounds()
+		r := a.b.Minus(a.a)
+		for j, b := range eb {
+			bb := Segment{A: b.a, B: b.b}.Bounds()
+			if ba.Right() < bb.Left() || bb.Right() < ba.Left() || ba.Bottom() < bb.Top() || bb.Bottom() < ba.Top() {
+				continue
+			}
+			// Ends that lie on the other edge, which covers touching and overlapping edges.
+			for _, v := range []Vec{b.a, b.b} {
+				if onEdge(a, v) {
+					splitsA[i] = append(splitsA[i], v)
+				}
+			}
+			for _, v := range []Vec{a.a, a.b} {
+				if onEdge(b, v) {
+					splitsB[j] = append(splitsB[j], v)
+				}
+			}
+			// Proper crossings.
+			s := b.b.Minus(b.a)
+			denom := cross(r, s)
+			if math.Abs(denom) <= eps*r.Len()*s.Len() {
+				continue
+			}
+			t := cross(b.a.Minus(a.a), s) / denom
+			u := cross(b.a.Minus(a.a), r) / denom
+			if t > eps && t < 1-eps && u > eps && u < 1-eps {
+				v := a.a.Plus(r.Times(t))
+				splitsA[i] = append(splitsA[i], v)
+				splitsB[j] = append(splitsB[j], v)
+			}
+		}
+	}
+	return applySplits(ea, splitsA), applySplits(eb, splitsB)
+}
+
+func applySplits(edges []boolEdge, splits [][]Vec) []boolEdge {
+	result := make([]boolEdge, 0, len(edges))
+	for i, e := range edges {
+		points := splits[i]
+		sort.Slice(points, func(j, k int) bool {
+			return points[j].Dist2(e.a) < points[k].Dist2(e.a)
+		})
+		prev := e.a
+		for _, v := range append(points, e.b) {
+			if v != prev {
+				result = append(result, boolEdge{prev, v})
+				prev = v
+			}
+		}
+	}
+	return result
+}
+
+// linkEdges joins directed edges end to start into closed outlines. Where there is a
+// choice it takes the sharpest clockwise turn, which keeps outlines that touch at a point
+// separate.
+func linkEdges(edges []boolEdge) []Polygon {
+	from := make(map[Vec][]int, len(edges))
+	for i, e := range edges {
+		from[e.a] = append(from[e.a], i)
+	}
+	used := make([]bool, len(edges))
+	result := []Polygon{}
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		outline := Polygon{}
+		closed := false
+		for e := start; e >= 0; {
+			used[e] = true
+			outline = append(outline, edges[e].a)
+			if edges[e].b == edges[start].a {
+				closed = true
+				break
+			}
+			d := edges[e].b.Minus(edges[e].a)
+			next, best := -1, math.Inf(-1)
+			for _, n := range from[edges[e].b] {
+				if used[n] {
+					continue
+				}
+				nd := edges[n].b.Minus(edges[n].a)
+				if turn := math.Atan2(cross(d, nd), d.Dot(nd)); turn > best {
+					next, best = n, turn
+				}
+			}
+			e = next
+		}
+		if !closed {
+			continue
+		}
+		if outline = removeCollinear(outline); len(outline) >= 3 && outline.Area() > 0 {
+			result = append(result, outline)
+		}
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// regionArea returns the area of a result region, where holes wind counterclockwise.
+func regionArea(region []Polygon) float64 {
+	a := 0.0
+	for _, p := range region {
+		a += p.signedArea() / 2
+	}
+	return a
+}
+
+func TestPolygonBoolean(t *testing.T) {
+	a := []Polygon{RectPolygon(Rect{X: 0, Y: 0, W: 2, H: 2})}
+	b := []Polygon{RectPolygon(Rect{X: 1, Y: 1, W: 2, H: 2}).Reversed()}
+	beside := []Polygon{RectPolygon(Rect{X: 2, Y: 0, W: 1, H: 2})}
+	far := []Polygon{RectPolygon(Rect{X: 5, Y: 5, W: 1, H: 1})}
+	inner := []Polygon{RectPolygon(Rect{X: 0.5, Y: 0.5, W: 1, H: 1})}
+	ring := PolygonDifference(a, inner)
+	diamond := []Polygon{{{X: 1, Y: -0.5}, {X: 2.5, Y: 1}, {X: 1, Y: 2.5}, {X: -0.5, Y: 1}}}
+
+	cases := []struct {
+		name     string
+		got      []Polygon
+		outlines int
+		area     float64
+	}{
+		{"union", PolygonUnion(a, b), 1, 7},
+		{"intersection", PolygonIntersection(a, b), 1, 1},
+		{"difference", PolygonDifference(a, b), 1, 3},
+		{"union beside", PolygonUnion(a, beside), 1, 6},
+		{"intersection beside", PolygonIntersection(a, beside), 0, 0},
+		{"difference beside", PolygonDifference(a, beside), 1, 4},
+		{"union far", PolygonUnion(a, far), 2, 5},
+		{"intersection far", PolygonIntersection(a, far), 0, 0},
+		{"difference far", PolygonDifference(a, far), 1, 4},
+		{"union same", PolygonUnion(a, a), 1, 4},
+		{"intersection same", PolygonIntersection(a, a), 1, 4},
+		{"difference same", PolygonDifference(a, a), 0, 0},
+		{"hole", ring, 2, 3},
+		{"fill hole", PolygonUnion(ring, inner), 1, 4},
+		{"ring minus b", PolygonDifference(ring, b), 1, 2.25},
+		{"ring and b", PolygonIntersection(ring, b), 1, 0.75},
+		{"diamond", PolygonIntersection(a, diamond), 1, 3.5},
+		{"diamond union", PolygonUnion(a, diamond), 1, 5},
+	}
+
+	for _, c := range cases {
+		if len(c.got) != c.outlines {
+			t.Errorf("%s: got %d outlines, want %d: %#v", c.name, len(c.got), c.outlines, c.got)
+		}
+		if area := regionArea(c.got); math.Abs(area-c.area) > e {
+			t.Errorf("%s: got area %v, want %v", c.name, area, c.area)
+		}
+	}
+
+	holes := 0
+	for _, p := range ring {
+		if !p.Clockwise() {
+			holes++
+		}
+	}
+	if holes != 1 {
+		t.Errorf("got %d counterclockwise holes, want 1", holes)
+	}
+}
+
+func TestPolygonBooleanRandom(t *testing.T) {
+	r := NewRngSeed(2)
+	pos := r.RandVecRect(Rect{W: 10, H: 10})
+	terrain := []Polygon{RectPolygon(Rect{X: 0, Y: 0, W: 10, H: 10})}
+	for i := 0; i < 20; i++ {
+		c := pos()
+		blast := []Polygon{CirclePolygon(Circle{X: c.X, Y: c.Y, R: 1 + r.Float64()}, 12)}
+		before := regionArea(terrain)
+		overlap := regionArea(PolygonIntersection(terrain, blast))
+		terrain = PolygonDifference(terrain, blast)
+		if after := regionArea(terrain); math.Abs(before-overlap-after) > 1e-6 {
+			t.Fatalf("blast %d: area went from %v to %v, but the overlap was %v", i, before, after, overlap)
+		}
+	}
+
+	// Triangulating the result should cover the same area.
+	area := 0.0
+	for _, g := range GroupHoles(terrain) {
+		for _, tri := range Triangulate(g[0], g[1:]...) {
+			area += tri.Area()
+		}
+	}
+	if want := regionArea(terrain); math.Abs(area-want) > 1e-6 {
+		t.Errorf("triangulated area %v, want %v", area, want)
+	}
+}
+
+func TestCirclePolygon(t *testing.T) {
+	p := CirclePolygon(Circle{X: 1, Y: 2, R: 3}, 4)
+	want := Polygon{{X: 4, Y: 2}, {X: 1, Y: 5}, {X: -2, Y: 2}, {X: 1, Y: -1}}
+	for i := range want {
+		if !p[i].Equals(want[i], e) {
+			t.Errorf("got %#v, want %#v", p, want)
+			break
+		}
+	}
+	if !p.Clockwise() {
+		t.Errorf("not clockwise")
+	}
+}
@@ -0,0 +1,227 @@
+package geo
+
+import "math"
+
+// Circle defines a circle by its center and radius.
+type Circle struct {
+	X, Y, R float64
+}
+
+// Center returns the center coordinates.
+func (c Circle) Center() (x, y float64) {
+	return c.X, c.Y
+}
+
+// SetCenter sets the center coordinates.
+func (c *Circle) SetCenter(x, y float64) {
+	c.X = x
+	c.Y = y
+}
+
+// Pos returns the center as a Vec.
+func (c Circle) Pos() Vec {
+	return Vec{X: c.X, Y: c.Y}
+}
+
+// SetPos sets the center from a Vec.
+func (c *Circle) SetPos(pos Vec) {
+	c.X = pos.X
+	c.Y = pos.Y
+}
+
+// Area returns the area of the circle.
+func (c Circle) Area() float64 {
+	return math.Pi * c.R * c.R
+}
+
+// Bounds returns the smallest Rect that contains the circle.
+func (c Circle) Bounds() Rect {
+	return Rect{X: c.X - c.R, Y: c.Y - c.R, W: 2 * c.R, H: 2 * c.R}
+}
+
+// Move moves the Circle by the given offset, in place.
+func (c *Circle) Move(dx, dy float64) {
+	c.X += dx
+	c.Y += dy
+}
+
+// Moved returns a new Circle moved by the given offset relative to this one.
+func (c Circle) Moved(dx, dy float64) Circle {
+	return Circle{X: c.X + dx, Y: c.Y + dy, R: c.R}
+}
+
+// Inflate keeps the same center but changes the radius by the given amount, in place.
+func (c *Circle) Inflate(dr float64) {
+	c.R += dr
+}
+
+// Inflated returns a new Circle with the same center whose radius is changed by the given
+// amount.
+func (c Circle) Inflated(dr float64) Circle {
+	return Circle{X: c.X, Y: c.Y, R: c.R + dr}
+}
+
+// Contains returns true if other is completely inside this one.
+func (c Circle) Contains(other Circle) bool {
+	if other.R > c.R {
+		return false
+	}
+	dr := c.R - other.R
+	return (c.X-other.X)*(c.X-other.X)+(c.Y-other.Y)*(c.Y-other.Y) <= dr*dr
+}
+
+// ContainsRect returns true if r is completely inside the circle.
+func (c Circle) ContainsRect(r Rect) bool {
+	r2 := c.R * c.R
+	for _, p := range [4]Vec{
+		{X: r.Left(), Y: r.Top()}, {X: r.Right(), Y: r.Top()},
+		{X: r.Left(), Y: r.Bottom()}, {X: r.Right(), Y: r.Bottom()},
+	} {
+		if (p.X-c.X)*(p.X-c.X)+(p.Y-c.Y)*(p.Y-c.Y) > r2 {
+			return false
+		}
+	}
+	return true
+}
+
+// CollidePoint returns true if the point is within the Circle. A point exactly on the
+// edge is not considered inside.
+func (c Circle) CollidePoint(x, y float64) bool {
+	return (x-c.X)*(x-c.X)+(y-c.Y)*(y-c.Y) < c.R*c.R
+}
+
+// CollideCircle returns true if the Circles overlap.
+func (c Circle) CollideCircle(other Circle) bool {
+	r := c.R + other.R
+	return (c.X-other.X)*(c.X-other.X)+(c.Y-other.Y)*(c.Y-other.Y) < r*r
+}
+
+// CollideRect returns true if the Circle and Rect overlap.
+func (c Circle) CollideRect(r Rect) bool {
+	x := clamp(c.X, r.Left(), r.Right())
+	y := clamp(c.Y, r.Top(), r.Bottom())
+	return (x-c.X)*(x-c.X)+(y-c.Y)*(y-c.Y) < c.R*c.R
+}
+
+// ClosestPoint returns the point within the Circle that is closest to v. If v is inside
+// the Circle then v is returned.
+func (c Circle) ClosestPoint(v Vec) Vec {
+	d := v.Minus(c.Pos())
+	if d.Len2() <= c.R*c.R {
+		return v
+	}
+	return c.Pos().Plus(d.WithLen(c.R))
+}
+
+// Dist returns the distance from v to the closest point within the Circle, or 0 if v is
+// inside.
+func (c Circle) Dist(v Vec) float64 {
+	return math.Max(v.Dist(c.Pos())-c.R, 0)
+}
+
+// CollideList returns the index of the first Circle this one collides with, or -1 if it
+// collides with none.
+func (c Circle) CollideList(others []Circle) int {
+	for i, other := range others {
+		if c.CollideCircle(other) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CollideListAll returns a list of indices of the Circles that collide with this one, or
+// an empty list if none.
+func (c Circle) CollideListAll(others []Circle) []int {
+	list := make([]int, 0, len(others))
+	for i, other := range others {
+		if c.CollideCircle(other) {
+			list = append(list, i)
+		}
+	}
+	return list
+}
+
+// CollideRectList returns the index of the first Rect this Circle collides with, or -1
+// if it collides with none.
+func (c Circle) CollideRectList(others []Rect) int {
+	for i, other := range others {
+		if c.CollideRect(other) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CollideRectListAll returns a list of indices of the Rects that collide with this Circle,
+// or an empty list if none.
+func (c Circle) CollideRectListAll(others []Rect) []int {
+	list := make([]int, 0, len(others))
+	for i, other := range others {
+		if c.CollideRect(other) {
+			list = append(list, i)
+		}
+	}
+	return list
+}
+
+// PenetrationCircle returns how far the two Circles overlap and the unit normal pointing
+// from c towards other. Moving c by normal.Times(-depth), or other by normal.Times(depth),
+// separates them. If they don't overlap then depth is 0 and normal is the zero vector. If
+// the centers coincide the normal is arbitrarily +x.
+func (c Circle) PenetrationCircle(other Circle) (depth float64, normal Vec) {
+	d := Vec{X: other.X - c.X, Y: other.Y - c.Y}
+	dist := d.Len()
+	depth = c.R + other.R - dist
+	if depth <= 0 {
+		return 0, Vec{}
+	}
+	if dist == 0 {
+		return depth, Vec{X: 1}
+	}
+	return depth, d.DividedBy(dist)
+}
+
+// PenetrationRect returns how far the Circle and Rect overlap and the unit normal pointing
+// from c towards r. Moving c by normal.Times(-depth), or r by normal.Times(depth),
+// separates them. If they don't overlap then depth is 0 and normal is the zero vector.
+func (c Circle) PenetrationRect(r Rect) (depth float64, normal Vec) {
+	closest := Vec{X: clamp(c.X, r.Left(), r.Right()), Y: clamp(c.Y, r.Top(), r.Bottom())}
+	d := closest.Minus(c.Pos())
+	if d.X != 0 || d.Y != 0 {
+		dist := d.Len()
+		if dist >= c.R {
+			return 0, Vec{}
+		}
+		return c.R - dist, d.DividedBy(dist)
+	}
+
+	// The center is inside the Rect so push out through the nearest edge.
+	left, right := c.X-r.Left(), r.Right()-c.X
+	top, bottom := c.Y-r.Top(), r.Bottom()-c.Y
+	min := left
+	normal = Vec{X: 1}
+	if right < min {
+		min, normal = right, Vec{X: -1}
+	}
+	if top < min {
+		min, normal = top, Vec{Y: 1}
+	}
+	if bottom < min {
+		min, normal = bottom, Vec{Y: -1}
+	}
+	return c.R + min, normal
+}
+
+// CollidePolygon returns true if the Circle and Polygon overlap.
+func (c Circle) CollidePolygon(p Polygon) bool {
+	return p.CollideCircle(c)
+}
+
+// PenetrationPolygon returns how far the Circle and Polygon overlap and the unit normal
+// pointing from c towards p. Moving c by normal.Times(-depth), or p by normal.Times(depth),
+// separates them. If they don't overlap then depth is 0 and normal is the zero vector.
+func (c Circle) PenetrationPolygon(p Polygon) (depth float64, normal Vec) {
+	depth, normal = p.PenetrationCircle(c)
+	return depth, normal.Times(-1)
+}
@@ -0,0 +1,171 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCircleContains(t *testing.T) {
+	cases := []struct {
+		c1, c2 Circle
+		want   bool
+	}{
+		{Circle{X: 0, Y: 0, R: 5}, Circle{X: 1, Y: 1, R: 1}, true},
+		{Circle{X: 0, Y: 0, R: 5}, Circle{X: 4, Y: 0, R: 1}, true},
+		{Circle{X: 0, Y: 0, R: 5}, Circle{X: 4, Y: 1, R: 1}, false},
+		{Circle{X: 0, Y: 0, R: 5}, Circle{X: 0, Y: 0, R: 6}, false},
+	}
+
+	for i, c := range cases {
+		got := c.c1.Contains(c.c2)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestCircleContainsRect(t *testing.T) {
+	cases := []struct {
+		c    Circle
+		r    Rect
+		want bool
+	}{
+		{Circle{X: 0, Y: 0, R: 5}, Rect{X: -3, Y: -3, W: 6, H: 6}, true},
+		{Circle{X: 0, Y: 0, R: 5}, Rect{X: -4, Y: -4, W: 8, H: 8}, false},
+		{Circle{X: 0, Y: 0, R: 5}, Rect{X: 10, Y: 10, W: 1, H: 1}, false},
+	}
+
+	for i, c := range cases {
+		got := c.c.ContainsRect(c.r)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestCircleCollidePoint(t *testing.T) {
+	cases := []struct {
+		c    Circle
+		x, y float64
+		want bool
+	}{
+		{Circle{X: 1, Y: 1, R: 2}, 1, 1, true},
+		{Circle{X: 1, Y: 1, R: 2}, 2, 2, true},
+		{Circle{X: 1, Y: 1, R: 2}, 3, 1, false},
+		{Circle{X: 1, Y: 1, R: 2}, 3, 3, false},
+	}
+
+	for i, c := range cases {
+		got := c.c.CollidePoint(c.x, c.y)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestCircleCollideCircle(t *testing.T) {
+	cases := []struct {
+		c1, c2 Circle
+		want   bool
+	}{
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 1, Y: 0, R: 1}, true},
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 2, Y: 0, R: 1}, false},
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 2, Y: 2, R: 1}, false},
+		{Circle{X: 0, Y: 0, R: 5}, Circle{X: 1, Y: 1, R: 1}, true},
+	}
+
+	for i, c := range cases {
+		got := c.c1.CollideCircle(c.c2)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestCircleCollideRect(t *testing.T) {
+	cases := []struct {
+		c    Circle
+		r    Rect
+		want bool
+	}{
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: 0.5, Y: -5, W: 2, H: 10}, true},
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: 1, Y: -5, W: 2, H: 10}, false},
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: 0.8, Y: 0.8, W: 2, H: 2}, false},
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: 0.5, Y: 0.5, W: 2, H: 2}, true},
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: -5, Y: -5, W: 10, H: 10}, true},
+	}
+
+	for i, c := range cases {
+		got := c.c.CollideRect(c.r)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+		got = c.r.CollideCircle(c.c)
+		if got != c.want {
+			t.Errorf("reverse case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestCircleCollideList(t *testing.T) {
+	c := Circle{X: 0, Y: 0, R: 1}
+	others := []Circle{{X: 5, Y: 5, R: 1}, {X: 1, Y: 1, R: 1}, {X: -1, Y: 0, R: 0.5}}
+	if got := c.CollideList(others); got != 1 {
+		t.Errorf("CollideList: got %d, want 1", got)
+	}
+	got := c.CollideListAll(others)
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("CollideListAll: got %#v, want [1 2]", got)
+	}
+	if got := c.CollideList(others[:1]); got != -1 {
+		t.Errorf("CollideList none: got %d, want -1", got)
+	}
+}
+
+func TestCirclePenetrationCircle(t *testing.T) {
+	cases := []struct {
+		c1, c2 Circle
+		depth  float64
+		normal Vec
+	}{
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 1.5, Y: 0, R: 1}, 0.5, Vec{X: 1}},
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 0, Y: -1, R: 1}, 1, Vec{Y: -1}},
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 3, Y: 0, R: 1}, 0, Vec{}},
+		{Circle{X: 0, Y: 0, R: 1}, Circle{X: 0, Y: 0, R: 1}, 2, Vec{X: 1}},
+	}
+
+	for i, c := range cases {
+		depth, normal := c.c1.PenetrationCircle(c.c2)
+		if math.Abs(depth-c.depth) > e || !normal.Equals(c.normal, e) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, depth, normal, c.depth, c.normal)
+		}
+		if depth > 0 && c.c1.Moved(normal.X*-depth, normal.Y*-depth).CollideCircle(c.c2.Inflated(-e)) {
+			t.Errorf("case %d: not separated after resolving", i)
+		}
+	}
+}
+
+func TestCirclePenetrationRect(t *testing.T) {
+	cases := []struct {
+		c      Circle
+		r      Rect
+		depth  float64
+		normal Vec
+	}{
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: 0.5, Y: -5, W: 2, H: 10}, 0.5, Vec{X: 1}},
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: -5, Y: -5, W: 10, H: 4.5}, 0.5, Vec{Y: -1}},
+		{Circle{X: 0, Y: 0, R: 1}, Rect{X: 2, Y: 2, W: 1, H: 1}, 0, Vec{}},
+		// Center inside, nearest edge is the left one.
+		{Circle{X: 1, Y: 5, R: 1}, Rect{X: 0, Y: 0, W: 10, H: 10}, 2, Vec{X: 1}},
+	}
+
+	for i, c := range cases {
+		depth, normal := c.c.PenetrationRect(c.r)
+		if math.Abs(depth-c.depth) > e || !normal.Equals(c.normal, e) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, depth, normal, c.depth, c.normal)
+		}
+		if depth > 0 && c.c.Moved(normal.X*-depth, normal.Y*-depth).Inflated(-e).CollideRect(c.r) {
+			t.Errorf("case %d: not separated after resolving", i)
+		}
+	}
+}
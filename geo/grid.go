@@ -0,0 +1,88 @@
+package geo
+
+// Grid is a fixed size 2D array of values, such as the tiles of a tile map. The Points in
+// a Grid go from (0, 0) to (W-1, H-1) and all access is bounds checked. Use NewGrid to
+// create one.
+type Grid[T any] struct {
+	w, h  int
+	cells []T
+}
+
+// NewGrid creates a Grid of the given size where every value is the zero value of T.
+// Negative sizes are treated as 0.
+func NewGrid[T any](w, h int) *Grid[T] {
+	w, h = maxInt(w, 0), maxInt(h, 0)
+	return &Grid[T]{w: w, h: h, cells: make([]T, w*h)}
+}
+
+// Size returns the width and height of the Grid.
+func (g *Grid[T]) Size() (w, h int) {
+	return g.w, g.h
+}
+
+// Bounds returns the IRect covering all of the Grid's Points.
+func (g *Grid[T]) Bounds() IRect {
+	return IRect{W: g.w, H: g.h}
+}
+
+// In returns true if p is inside the Grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.X < g.w && p.Y >= 0 && p.Y < g.h
+}
+
+// At returns the value at p. If p is outside the Grid then ok is false and the zero value
+// is returned.
+func (g *Grid[T]) At(p Point) (v T, ok bool) {
+	if !g.In(p) {
+		return v, false
+	}
+	return g.cells[p.Y*g.w+p.X], true
+}
+
+// Get returns the value at p, or the zero value if p is outside the Grid.
+func (g *Grid[T]) Get(p Point) T {
+	v, _ := g.At(p)
+	return v
+}
+
+// Set sets the value at p. It returns false and does nothing if p is outside the Grid.
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.In(p) {
+		return false
+	}
+	g.cells[p.Y*g.w+p.X] = v
+	return true
+}
+
+// Fill sets every value in the Grid to v.
+func (g *Grid[T]) Fill(v T) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// FillIRect sets every value within r to v. The parts of r outside the Grid are ignored.
+func (g *Grid[T]) FillIRect(r IRect, v T) {
+	r = r.Intersect(g.Bounds())
+	for y := r.Y; y < r.Bottom(); y++ {
+		for x := r.X; x < r.Right(); x++ {
+			g.cells[y*g.w+x] = v
+		}
+	}
+}
+
+// ForEach calls f with each Point in the Grid and its value, row by row.
+func (g *Grid[T]) ForEach(f func(p Point, v T)) {
+	for i, v := range g.cells {
+		f(Point{X: i % g.w, Y: i / g.w}, v)
+	}
+}
+
+// FloodFill is the same as the package level FloodFill, limited to the Grid and with
+// passable given each Point's value.
+func (g *Grid[T]) FloodFill(start Point, n Neighborhood, passable func(v T) bool) []Point {
+	return FloodFill(start, n, func(p Point) bool {
+		v, ok := g.At(p)
+		return ok && passable(v)
+	})
+}
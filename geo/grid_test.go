@@ -0,0 +1,53 @@
+package geo
+
+import "testing"
+
+func TestGrid(t *testing.T) {
+	g := NewGrid[rune](4, 3)
+	if w, h := g.Size(); w != 4 || h != 3 {
+		t.Errorf("got size %d, %d", w, h)
+	}
+	g.Fill('.')
+	g.FillIRect(IRect{X: 2, Y: -1, W: 10, H: 2}, '#')
+
+	cases := []struct {
+		p    Point
+		want rune
+		ok   bool
+	}{
+		{Point{X: 0, Y: 0}, '.', true},
+		{Point{X: 2, Y: 0}, '#', true},
+		{Point{X: 3, Y: 0}, '#', true},
+		{Point{X: 3, Y: 1}, '.', true},
+		{Point{X: 4, Y: 0}, 0, false},
+		{Point{X: 0, Y: -1}, 0, false},
+	}
+	for i, c := range cases {
+		if got, ok := g.At(c.p); got != c.want || ok != c.ok {
+			t.Errorf("case %d: got %q %v, want %q %v", i, got, ok, c.want, c.ok)
+		}
+	}
+
+	if g.Set(Point{X: 4, Y: 4}, 'x') {
+		t.Errorf("Set outside returned true")
+	}
+	if !g.Set(Point{X: 1, Y: 2}, 'x') || g.Get(Point{X: 1, Y: 2}) != 'x' {
+		t.Errorf("Set inside failed")
+	}
+
+	count := 0
+	g.ForEach(func(p Point, v rune) {
+		if v == '#' && p.X < 2 {
+			t.Errorf("unexpected # at %v", p)
+		}
+		count++
+	})
+	if count != 12 {
+		t.Errorf("ForEach visited %d, want 12", count)
+	}
+
+	open := g.FloodFill(Point{}, Neighbors4, func(v rune) bool { return v == '.' })
+	if len(open) != 12-2-1 {
+		t.Errorf("FloodFill got %d points, want %d", len(open), 12-2-1)
+	}
+}
@@ -0,0 +1,47 @@
+package geo
+
+import "sort"
+
+// ConvexHull returns the smallest convex Polygon that contains all of points, wound
+// clockwise, using Andrew's monotone chain algorithm. Points along the hull's edges are
+// not included as vertices. If there are fewer than 3 distinct points, or they are all in
+// a line, then the result has fewer than 3 vertices.
+func ConvexHull(points []Vec) Polygon {
+	sorted := make([]Vec, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	unique := sorted[:0]
+	for _, v := range sorted {
+		if len(unique) == 0 || v != unique[len(unique)-1] {
+			unique = append(unique, v)
+		}
+	}
+	sorted = unique
+
+	hull := make(Polygon, 0, 2*len(sorted))
+	// Lower half followed by the upper half, the cross product discards any point that
+	// doesn't turn clockwise.
+	add := func(v Vec, min int) {
+		for len(hull) >= min && cross(hull[len(hull)-1].Minus(hull[len(hull)-2]), v.Minus(hull[len(hull)-1])) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, v)
+	}
+	for _, v := range sorted {
+		add(v, 2)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		add(sorted[i], lower)
+	}
+	if len(hull) > 1 {
+		// The last point is the same as the first.
+		hull = hull[:len(hull)-1]
+	}
+	return hull
+}
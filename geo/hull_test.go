@@ -0,0 +1,48 @@
+package geo
+
+import "testing"
+
+func TestConvexHull(t *testing.T) {
+	cases := []struct {
+		points []Vec
+		want   Polygon
+	}{
+		{nil, Polygon{}},
+		{[]Vec{{X: 1, Y: 1}, {X: 1, Y: 1}}, Polygon{{X: 1, Y: 1}}},
+		{[]Vec{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 1, Y: 1}}, Polygon{{X: 0, Y: 0}, {X: 2, Y: 2}}},
+		{
+			[]Vec{{X: 1, Y: 1}, {X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 1, Y: 0.5}},
+			Polygon{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+		},
+	}
+
+	for i, c := range cases {
+		got := ConvexHull(c.points)
+		if len(got) != len(c.want) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+			continue
+		}
+		for j := range got {
+			if got[j] != c.want[j] {
+				t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+				break
+			}
+		}
+	}
+
+	r := NewRngSeed(1)
+	gen := r.RandVecCircle(0, 100)
+	points := make([]Vec, 200)
+	for i := range points {
+		points[i] = gen()
+	}
+	hull := ConvexHull(points)
+	if !hull.Clockwise() || !convexClockwise(hull) {
+		t.Errorf("hull isn't convex and clockwise: %#v", hull)
+	}
+	for _, p := range points {
+		if hull.Dist(p) > e {
+			t.Errorf("point %#v is outside the hull", p)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package geo
+
+import "math"
+
+// IRect is a rectangle on an integer grid, such as an area of tiles in a tile map. It
+// covers the Points from (X, Y) up to but not including (X+W, Y+H).
+type IRect struct {
+	X, Y, W, H int
+}
+
+// TilesIn returns the smallest IRect of tiles that covers r, where each tile is tileW by
+// tileH. A tile that r only touches along its right or bottom edge isn't included.
+func TilesIn(r Rect, tileW, tileH float64) IRect {
+	left, top := int(math.Floor(r.Left()/tileW)), int(math.Floor(r.Top()/tileH))
+	right, bottom := int(math.Ceil(r.Right()/tileW)), int(math.Ceil(r.Bottom()/tileH))
+	return IRect{X: left, Y: top, W: right - left, H: bottom - top}
+}
+
+// TileRect returns the area covered by the tiles in r, where each tile is tileW by tileH.
+func (r IRect) TileRect(tileW, tileH float64) Rect {
+	return Rect{X: float64(r.X) * tileW, Y: float64(r.Y) * tileH, W: float64(r.W) * tileW, H: float64(r.H) * tileH}
+}
+
+// Rect returns r as a Rect.
+func (r IRect) Rect() Rect {
+	return Rect{X: float64(r.X), Y: float64(r.Y), W: float64(r.W), H: float64(r.H)}
+}
+
+// Right returns the right boundary, which is one past the last column.
+func (r IRect) Right() int {
+	return r.X + r.W
+}
+
+// Bottom returns the bottom boundary, which is one past the last row.
+func (r IRect) Bottom() int {
+	return r.Y + r.H
+}
+
+// TopLeft returns the first Point in r.
+func (r IRect) TopLeft() Point {
+	return Point{X: r.X, Y: r.Y}
+}
+
+// Area returns the number of Points in r.
+func (r IRect) Area() int {
+	if r.Empty() {
+		return 0
+	}
+	return r.W * r.H
+}
+
+// Empty returns true if r contains no Points.
+func (r IRect) Empty() bool {
+	return r.W <= 0 || r.H <= 0
+}
+
+// Move moves the IRect by the given offset, in place.
+func (r *IRect) Move(dx, dy int) {
+	r.X += dx
+	r.Y += dy
+}
+
+// Moved returns a new IRect moved by the given offset relative to this one.
+func (r IRect) Moved(dx, dy int) IRect {
+	return IRect{X: r.X + dx, Y: r.Y + dy, W: r.W, H: r.H}
+}
+
+// Inflate keeps the same center but changes the size by the given amounts, in place.
+// Growing by an odd amount puts the extra row or column on the right or bottom.
+func (r *IRect) Inflate(dw, dh int) {
+	*r = r.Inflated(dw, dh)
+}
+
+// Inflated returns a new IRect with the same center whose size is changed by the given
+// amounts.
+func (r IRect) Inflated(dw, dh int) IRect {
+	return IRect{X: r.X - dw/2, Y: r.Y - dh/2, W: r.W + dw, H: r.H + dh}
+}
+
+// Intersect returns a new IRect that marks the area where the two overlap. If there is
+// no intersection the returned IRect will be Empty.
+func (r IRect) Intersect(other IRect) IRect {
+	x, y := maxInt(r.X, other.X), maxInt(r.Y, other.Y)
+	return IRect{
+		X: x,
+		Y: y,
+		W: maxInt(minInt(r.Right(), other.Right())-x, 0),
+		H: maxInt(minInt(r.Bottom(), other.Bottom())-y, 0),
+	}
+}
+
+// Unioned returns a new IRect that contains both IRects.
+func (r IRect) Unioned(other IRect) IRect {
+	x, y := minInt(r.X, other.X), minInt(r.Y, other.Y)
+	return IRect{
+		X: x,
+		Y: y,
+		W: maxInt(r.Right(), other.Right()) - x,
+		H: maxInt(r.Bottom(), other.Bottom()) - y,
+	}
+}
+
+// Contains returns true if other is completely inside r.
+func (r IRect) Contains(other IRect) bool {
+	return other.X >= r.X && other.Right() <= r.Right() && other.Y >= r.Y && other.Bottom() <= r.Bottom()
+}
+
+// CollidePoint returns true if the Point (x, y) is within the IRect.
+func (r IRect) CollidePoint(x, y int) bool {
+	return x >= r.X && x < r.Right() && y >= r.Y && y < r.Bottom()
+}
+
+// CollideIRect returns true if the IRects overlap.
+func (r IRect) CollideIRect(other IRect) bool {
+	return r.X < other.Right() && r.Right() > other.X && r.Y < other.Bottom() && r.Bottom() > other.Y
+}
+
+// ClampPoint returns the Point in r that is closest to p. If r is Empty then the result is
+// undefined.
+func (r IRect) ClampPoint(p Point) Point {
+	return Point{X: clampInt(p.X, r.X, r.Right()-1), Y: clampInt(p.Y, r.Y, r.Bottom()-1)}
+}
+
+// Points returns every Point in r, row by row.
+func (r IRect) Points() []Point {
+	points := make([]Point, 0, r.Area())
+	for y := r.Y; y < r.Bottom(); y++ {
+		for x := r.X; x < r.Right(); x++ {
+			points = append(points, Point{X: x, Y: y})
+		}
+	}
+	return points
+}
@@ -0,0 +1,55 @@
+package geo
+
+import "testing"
+
+func TestTilesIn(t *testing.T) {
+	cases := []struct {
+		r    Rect
+		want IRect
+	}{
+		{Rect{X: 0, Y: 0, W: 16, H: 16}, IRect{X: 0, Y: 0, W: 1, H: 1}},
+		{Rect{X: 1, Y: 1, W: 16, H: 16}, IRect{X: 0, Y: 0, W: 2, H: 2}},
+		{Rect{X: -8, Y: 20, W: 40, H: 1}, IRect{X: -1, Y: 1, W: 3, H: 1}},
+	}
+
+	for i, c := range cases {
+		if got := TilesIn(c.r, 16, 16); got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+		if !c.want.TileRect(16, 16).Contains(c.r) {
+			t.Errorf("case %d: TileRect doesn't contain %#v", i, c.r)
+		}
+	}
+}
+
+func TestIRect(t *testing.T) {
+	r := IRect{X: 1, Y: 2, W: 3, H: 4}
+	cases := []struct {
+		got, want interface{}
+	}{
+		{r.Right(), 4},
+		{r.Bottom(), 6},
+		{r.Area(), 12},
+		{IRect{W: -1, H: 5}.Area(), 0},
+		{r.Moved(1, -1), IRect{X: 2, Y: 1, W: 3, H: 4}},
+		{r.Inflated(2, 3), IRect{X: 0, Y: 1, W: 5, H: 7}},
+		{r.Intersect(IRect{X: 3, Y: 0, W: 5, H: 3}), IRect{X: 3, Y: 2, W: 1, H: 1}},
+		{r.Intersect(IRect{X: 10, Y: 10, W: 1, H: 1}).Empty(), true},
+		{r.Unioned(IRect{X: 0, Y: 5, W: 1, H: 5}), IRect{X: 0, Y: 2, W: 4, H: 8}},
+		{r.Contains(IRect{X: 2, Y: 3, W: 2, H: 3}), true},
+		{r.Contains(IRect{X: 2, Y: 3, W: 3, H: 3}), false},
+		{r.CollidePoint(3, 5), true},
+		{r.CollidePoint(4, 5), false},
+		{r.CollideIRect(IRect{X: 4, Y: 2, W: 1, H: 1}), false},
+		{r.CollideIRect(IRect{X: 3, Y: 5, W: 10, H: 10}), true},
+		{r.ClampPoint(Point{X: -5, Y: 10}), Point{X: 1, Y: 5}},
+		{len(r.Points()), 12},
+		{r.Points()[4], Point{X: 2, Y: 3}},
+	}
+
+	for i, c := range cases {
+		if c.got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, c.got, c.want)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package geo
 
 import (
 	"math"
-	"math/rand"
+	"sort"
 )
 
 // NumGen (Number Generator) is a function that returns a number.
@@ -17,22 +17,142 @@ func ConstNum(n float64) NumGen {
 
 // RandNum returns a NumGen that returns a uniform random number between min and max.
 func RandNum(min, max float64) NumGen {
-	width := max - min
-	return func() float64 {
-		return rand.Float64()*width + min
-	}
+	return globalRng.RandNum(min, max)
 }
 
 // RandRadius returns a NumGen that returns a uniform circle radius between minR and maxR.
 func RandRadius(minR, maxR float64) NumGen {
-	if maxR == 0 || maxR == minR {
-		return func() float64 {
-			return maxR
+	return globalRng.RandRadius(minR, maxR)
+}
+
+// RandNormal returns a NumGen with a normal (Gaussian) distribution, which clusters around
+// mean with about two thirds of the numbers within stdDev of it. It is unbounded, so wrap
+// it in ClampNum if extreme values would be a problem.
+func RandNormal(mean, stdDev float64) NumGen {
+	return globalRng.RandNormal(mean, stdDev)
+}
+
+// RandTriangular returns a NumGen between min and max that is most likely to be near mode
+// and less likely the further away it is.
+func RandTriangular(min, mode, max float64) NumGen {
+	return globalRng.RandTriangular(min, mode, max)
+}
+
+// RandExp returns a NumGen with an exponential distribution with the given mean. It gives
+// the time between events that happen at random at an average rate of 1/mean, which makes
+// for natural looking spacing.
+func RandExp(mean float64) NumGen {
+	return globalRng.RandExp(mean)
+}
+
+// RandPoisson returns a NumGen of whole numbers with a Poisson distribution, which is the
+// number of events that happen in an interval if they happen at random with the given mean
+// number per interval.
+func RandPoisson(mean float64) NumGen {
+	return globalRng.RandPoisson(mean)
+}
+
+// RandWeighted returns a NumGen that returns one of values where the chance of each is
+// proportional to the corresponding weight. If all weights are 0 then each is equally
+// likely. values and weights must be the same length. If they are empty then 0 is returned.
+func RandWeighted(values, weights []float64) NumGen {
+	return globalRng.RandWeighted(values, weights)
+}
+
+// ChooseNum returns a NumGen that calls one of gens, chosen at random each time. If gens is
+// empty then 0 is returned.
+func ChooseNum(gens ...NumGen) NumGen {
+	return globalRng.ChooseNum(gens...)
+}
+
+// AddNum returns a NumGen that adds the results of a and b.
+func AddNum(a, b NumGen) NumGen {
+	return func() float64 {
+		return a() + b()
+	}
+}
+
+// SumNum returns a NumGen that adds the results of all of gens. The sum of several
+// uniform NumGens is a cheap approximation of a normal distribution.
+func SumNum(gens ...NumGen) NumGen {
+	return func() float64 {
+		sum := 0.0
+		for _, g := range gens {
+			sum += g()
+		}
+		return sum
+	}
+}
+
+// ScaleNum returns a NumGen that multiplies the result of gen by factor.
+func ScaleNum(gen NumGen, factor float64) NumGen {
+	return func() float64 {
+		return gen() * factor
+	}
+}
+
+// ClampNum returns a NumGen that limits the result of gen to be between min and max.
+func ClampNum(gen NumGen, min, max float64) NumGen {
+	return func() float64 {
+		return math.Max(min, math.Min(gen(), max))
+	}
+}
+
+// QuantizeNum returns a NumGen that rounds the result of gen to the nearest multiple of
+// step. If step is not positive then gen is returned unchanged.
+func QuantizeNum(gen NumGen, step float64) NumGen {
+	if step <= 0 {
+		return gen
+	}
+	return func() float64 {
+		return math.Round(gen()/step) * step
+	}
+}
+
+// MapNum returns a NumGen that passes the result of gen through f.
+func MapNum(gen NumGen, f func(float64) float64) NumGen {
+	return func() float64 {
+		return f(gen())
+	}
+}
+
+// CycleNum returns a NumGen that returns each of values in order, starting over after the
+// last one. If values is empty then 0 is returned.
+func CycleNum(values ...float64) NumGen {
+	i := -1
+	return func() float64 {
+		if len(values) == 0 {
+			return 0
 		}
+		i = (i + 1) % len(values)
+		return values[i]
 	}
-	unitMin := minR / maxR
-	unitMin *= unitMin
+}
+
+// CurveNum returns a NumGen that maps the result of gen through a piecewise-linear curve.
+// Each point's X is an input and Y is the output for it, with inputs between points
+// interpolated linearly and those outside of the points clamped to the nearest end. The
+// points must be sorted by X, and if there are none then 0 is returned. For example, to
+// make a NumGen that is most often small:
+//
+//	CurveNum(RandNum(0, 1), []Vec{{X: 0, Y: 0}, {X: 0.8, Y: 1}, {X: 1, Y: 10}})
+func CurveNum(gen NumGen, points []Vec) NumGen {
 	return func() float64 {
-		return math.Sqrt(rand.Float64()*(1-unitMin)+unitMin) * maxR
+		return curveAt(points, gen())
+	}
+}
+
+func curveAt(points []Vec, x float64) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	if x <= points[0].X {
+		return points[0].Y
+	}
+	i := sort.Search(len(points), func(i int) bool { return points[i].X > x })
+	if i == len(points) {
+		return points[len(points)-1].Y
 	}
+	a, b := points[i-1], points[i]
+	return a.Y + (b.Y-a.Y)*(x-a.X)/(b.X-a.X)
 }
@@ -0,0 +1,41 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumGenCombinators(t *testing.T) {
+	cases := []struct {
+		gen  NumGen
+		want []float64
+	}{
+		{AddNum(ConstNum(1), ConstNum(2)), []float64{3}},
+		{SumNum(ConstNum(1), ConstNum(2), ConstNum(3)), []float64{6}},
+		{SumNum(), []float64{0}},
+		{ScaleNum(CycleNum(1, -2), 3), []float64{3, -6, 3}},
+		{ClampNum(CycleNum(-1, 0.5, 2), 0, 1), []float64{0, 0.5, 1, 0}},
+		{QuantizeNum(CycleNum(0.2, 0.3, -0.7, 1.6), 0.5), []float64{0, 0.5, -0.5, 1.5}},
+		{QuantizeNum(CycleNum(0.2, -0.7), 0), []float64{0.2, -0.7}},
+		{QuantizeNum(CycleNum(0.2, 0.3), -0.5), []float64{0.2, 0.3}},
+		{MapNum(CycleNum(2, 3), func(n float64) float64 { return n * n }), []float64{4, 9}},
+		{CycleNum(1, 2, 3), []float64{1, 2, 3, 1, 2}},
+		{CurveNum(CycleNum(-1, 0, 0.4, 0.8, 0.9, 1, 2),
+			[]Vec{{X: 0, Y: 0}, {X: 0.8, Y: 1}, {X: 1, Y: 10}}),
+			[]float64{0, 0, 0.5, 1, 5.5, 10, 10}},
+		{CurveNum(CycleNum(-1, 5), []Vec{{X: 3, Y: 7}}), []float64{7, 7}},
+		{CycleNum(), []float64{0, 0}},
+		{CurveNum(ConstNum(1), nil), []float64{0}},
+		{ChooseNum(), []float64{0}},
+		{RandWeighted(nil, nil), []float64{0}},
+		{RandWeighted([]float64{1}, nil), []float64{0}},
+	}
+
+	for i, c := range cases {
+		for j, want := range c.want {
+			if got := c.gen(); math.Abs(got-want) > e {
+				t.Errorf("case %d call %d: got %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package geo
+
+import "math"
+
+// Point is a position on an integer grid, such as the coordinates of a tile in a tile map.
+type Point struct {
+	X, Y int
+}
+
+// TileAt returns the Point of the tile that contains v, where each tile is tileW by tileH
+// and tile (0, 0) has its top left corner at the origin.
+func TileAt(v Vec, tileW, tileH float64) Point {
+	return Point{X: int(math.Floor(v.X / tileW)), Y: int(math.Floor(v.Y / tileH))}
+}
+
+// Vec returns p as a Vec.
+func (p Point) Vec() Vec {
+	return Vec{X: float64(p.X), Y: float64(p.Y)}
+}
+
+// TileRect returns the area covered by the tile at p, where each tile is tileW by tileH.
+func (p Point) TileRect(tileW, tileH float64) Rect {
+	return Rect{X: float64(p.X) * tileW, Y: float64(p.Y) * tileH, W: tileW, H: tileH}
+}
+
+// TileCenter returns the center of the tile at p, where each tile is tileW by tileH.
+func (p Point) TileCenter(tileW, tileH float64) Vec {
+	return Vec{X: (float64(p.X) + 0.5) * tileW, Y: (float64(p.Y) + 0.5) * tileH}
+}
+
+// Add modifies p to be p + p2.
+func (p *Point) Add(p2 Point) {
+	p.X += p2.X
+	p.Y += p2.Y
+}
+
+// Plus returns a new Point that is p + p2.
+func (p Point) Plus(p2 Point) Point {
+	return Point{X: p.X + p2.X, Y: p.Y + p2.Y}
+}
+
+// Sub modifies p to be p - p2.
+func (p *Point) Sub(p2 Point) {
+	p.X -= p2.X
+	p.Y -= p2.Y
+}
+
+// Minus returns a new Point that is p - p2.
+func (p Point) Minus(p2 Point) Point {
+	return Point{X: p.X - p2.X, Y: p.Y - p2.Y}
+}
+
+// Mul modifies p to be p * n.
+func (p *Point) Mul(n int) {
+	p.X *= n
+	p.Y *= n
+}
+
+// Times returns a new Point that is p * n.
+func (p Point) Times(n int) Point {
+	return Point{X: p.X * n, Y: p.Y * n}
+}
+
+// Sign returns a new Point with each of p's coordinates replaced by -1, 0 or 1 depending on
+// its sign. This turns the offset between two Points in a line into a single step.
+func (p Point) Sign() Point {
+	return Point{X: signInt(p.X), Y: signInt(p.Y)}
+}
+
+// ManhattanDist returns the number of steps from p to p2 when only moving horizontally
+// and vertically.
+func (p Point) ManhattanDist(p2 Point) int {
+	return absInt(p.X-p2.X) + absInt(p.Y-p2.Y)
+}
+
+// ChebyshevDist returns the number of steps from p to p2 when diagonal moves are allowed.
+func (p Point) ChebyshevDist(p2 Point) int {
+	return maxInt(absInt(p.X-p2.X), absInt(p.Y-p2.Y))
+}
+
+// Neighborhood is a list of offsets to the neighbors of a Point.
+type Neighborhood []Point
+
+var (
+	// Neighbors4 is the four Points that share an edge, starting above and going clockwise.
+	Neighbors4 = Neighborhood{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+	// Neighbors8 is the eight Points that share an edge or corner, starting above and going
+	// clockwise.
+	Neighbors8 = Neighborhood{
+		{X: 0, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1},
+		{X: 0, Y: 1}, {X: -1, Y: 1}, {X: -1, Y: 0}, {X: -1, Y: -1},
+	}
+)
+
+// Neighbors returns the neighbors of p in the Neighborhood n.
+func (p Point) Neighbors(n Neighborhood) []Point {
+	points := make([]Point, len(n))
+	for i, offset := range n {
+		points[i] = p.Plus(offset)
+	}
+	return points
+}
+
+// BresenhamLine returns the Points on the line from a to b, including both, using
+// Bresenham's algorithm. Consecutive Points may touch only at the corners.
+func BresenhamLine(a, b Point) []Point {
+	dx, dy := absInt(b.X-a.X), -absInt(b.Y-a.Y)
+	sx, sy := signInt(b.X-a.X), signInt(b.Y-a.Y)
+	points := make([]Point, 0, maxInt(dx, -dy)+1)
+	err := dx + dy
+	for p := a; ; {
+		points = append(points, p)
+		if p == b {
+			return points
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			p.X += sx
+		}
+		if e2 <= dx {
+			err += dx
+			p.Y += sy
+		}
+	}
+}
+
+// SupercoverLine returns every Point whose tile is touched by the line from the center of
+// a to the center of b, including both. Each Point shares an edge with the one before it,
+// except where the line passes exactly through a corner, in which case both of the tiles
+// beside the corner are included. This is useful for line of sight, where BresenhamLine
+// could slip diagonally between two walls.
+func SupercoverLine(a, b Point) []Point {
+	nx, ny := absInt(b.X-a.X), absInt(b.Y-a.Y)
+	sx, sy := signInt(b.X-a.X), signInt(b.Y-a.Y)
+	points := make([]Point, 0, nx+ny+1)
+	p := a
+	points = append(points, p)
+	for ix, iy := 0, 0; ix < nx || iy < ny; {
+		// Compare how far along the line the next vertical and horizontal edges are.
+		switch d := (1+2*ix)*ny - (1+2*iy)*nx; {
+		case d == 0:
+			points = append(points, Point{X: p.X + sx, Y: p.Y}, Point{X: p.X, Y: p.Y + sy})
+			p.X += sx
+			p.Y += sy
+			ix++
+			iy++
+		case d < 0:
+			p.X += sx
+			ix++
+		default:
+			p.Y += sy
+			iy++
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// FloodFill returns all of the Points connected to start through the Neighborhood n for
+// which passable returns true, starting with start itself. passable must return false
+// for Points outside of the area of interest, otherwise FloodFill never ends. If start
+// isn't passable then nil is returned.
+func FloodFill(start Point, n Neighborhood, passable func(p Point) bool) []Point {
+	if !passable(start) {
+		return nil
+	}
+	seen := map[Point]bool{start: true}
+	points := []Point{start}
+	for i := 0; i < len(points); i++ {
+		for _, offset := range n {
+			next := points[i].Plus(offset)
+			if !seen[next] && passable(next) {
+				seen[next] = true
+				points = append(points, next)
+			}
+		}
+	}
+	return points
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func signInt(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
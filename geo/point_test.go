@@ -0,0 +1,124 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTileConversion(t *testing.T) {
+	cases := []struct {
+		v    Vec
+		want Point
+	}{
+		{Vec{X: 0, Y: 0}, Point{X: 0, Y: 0}},
+		{Vec{X: 15.9, Y: 8}, Point{X: 0, Y: 1}},
+		{Vec{X: 16, Y: 7.9}, Point{X: 1, Y: 0}},
+		{Vec{X: -0.1, Y: -8.1}, Point{X: -1, Y: -2}},
+	}
+
+	for i, c := range cases {
+		got := TileAt(c.v, 16, 8)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+		if r := got.TileRect(16, 8); !r.CollidePoint(c.v.X, c.v.Y) {
+			t.Errorf("case %d: %#v not in %#v", i, c.v, r)
+		}
+	}
+
+	if got, want := (Point{X: 2, Y: -1}).TileCenter(16, 8), (Vec{X: 40, Y: -4}); got != want {
+		t.Errorf("TileCenter: got %#v, want %#v", got, want)
+	}
+}
+
+func TestPointMath(t *testing.T) {
+	p := Point{X: 1, Y: 2}
+	p.Add(Point{X: 3, Y: 4})
+	p.Mul(2)
+	p.Sub(Point{X: 1, Y: 1})
+	if want := (Point{X: 7, Y: 11}); p != want {
+		t.Errorf("got %#v, want %#v", p, want)
+	}
+	if got := p.Minus(Point{X: 10, Y: 10}).Plus(Point{X: 1}).Times(3); got != (Point{X: -6, Y: 3}) {
+		t.Errorf("got %#v", got)
+	}
+	if got := (Point{X: -5, Y: 3}).Sign(); got != (Point{X: -1, Y: 1}) {
+		t.Errorf("Sign: got %#v", got)
+	}
+	if got := (Point{Y: -2}).Sign(); got != (Point{Y: -1}) {
+		t.Errorf("Sign: got %#v", got)
+	}
+	if d := p.ManhattanDist(Point{}); d != 18 {
+		t.Errorf("ManhattanDist: got %d, want 18", d)
+	}
+	if d := p.ChebyshevDist(Point{X: 10}); d != 11 {
+		t.Errorf("ChebyshevDist: got %d, want 11", d)
+	}
+	if got := (Point{}).Neighbors(Neighbors4); !reflect.DeepEqual(got, []Point(Neighbors4)) {
+		t.Errorf("Neighbors: got %#v", got)
+	}
+}
+
+func TestLines(t *testing.T) {
+	cases := []struct {
+		a, b       Point
+		bresenham  []Point
+		supercover []Point
+	}{
+		{
+			Point{X: 0, Y: 0}, Point{X: 0, Y: 0},
+			[]Point{{X: 0, Y: 0}},
+			[]Point{{X: 0, Y: 0}},
+		},
+		{
+			Point{X: 0, Y: 0}, Point{X: 3, Y: 1},
+			[]Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 1}, {X: 3, Y: 1}},
+			[]Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}},
+		},
+		{
+			Point{X: 2, Y: 2}, Point{X: 0, Y: 0},
+			[]Point{{X: 2, Y: 2}, {X: 1, Y: 1}, {X: 0, Y: 0}},
+			[]Point{{X: 2, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}},
+		},
+		{
+			Point{X: 0, Y: 0}, Point{X: -1, Y: 3},
+			[]Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 2}, {X: -1, Y: 3}},
+			[]Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 1}, {X: 0, Y: 2}, {X: -1, Y: 2}, {X: -1, Y: 3}},
+		},
+	}
+
+	for i, c := range cases {
+		if got := BresenhamLine(c.a, c.b); !reflect.DeepEqual(got, c.bresenham) {
+			t.Errorf("case %d: BresenhamLine got %v, want %v", i, got, c.bresenham)
+		}
+		if got := SupercoverLine(c.a, c.b); !reflect.DeepEqual(got, c.supercover) {
+			t.Errorf("case %d: SupercoverLine got %v, want %v", i, got, c.supercover)
+		}
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	// A ring of walls with a gap in the corner that can only be reached diagonally.
+	walls := map[Point]bool{}
+	for _, p := range (IRect{X: 0, Y: 0, W: 5, H: 5}).Points() {
+		if p.X == 0 || p.Y == 0 || p.X == 4 || p.Y == 4 {
+			walls[p] = true
+		}
+	}
+	walls[Point{X: 4, Y: 4}] = false
+	bounds := IRect{X: -1, Y: -1, W: 7, H: 7}
+	passable := func(p Point) bool {
+		return bounds.CollidePoint(p.X, p.Y) && !walls[p]
+	}
+
+	if got := FloodFill(Point{X: 1, Y: 1}, Neighbors4, passable); len(got) != 9 || got[0] != (Point{X: 1, Y: 1}) {
+		t.Errorf("Neighbors4: got %d points %v, want 9", len(got), got)
+	}
+	// The outside has 49 - 25 points plus the gap, and the inside leaks through it.
+	if got := FloodFill(Point{X: 1, Y: 1}, Neighbors8, passable); len(got) != 49-25+1+9 {
+		t.Errorf("Neighbors8: got %d points, want %d", len(got), 49-25+1+9)
+	}
+	if got := FloodFill(Point{}, Neighbors4, passable); got != nil {
+		t.Errorf("start in wall: got %v, want nil", got)
+	}
+}
@@ -0,0 +1,203 @@
+package geo
+
+import "math"
+
+// poissonTries is the number of candidates tried around each point before giving up on it.
+const poissonTries = 30
+
+// PoissonRect returns points within rect that are randomly placed but no closer than
+// spacing to each other, using Bridson's Poisson-disc sampling. Unlike RandVecRect the
+// points are evenly spread without clumps or gaps, which looks more natural for things
+// like trees, stars or spawn points. It returns nil if spacing is not positive.
+func PoissonRect(rect Rect, spacing float64) []Vec {
+	return globalRng.PoissonRect(rect, spacing)
+}
+
+// PoissonRects is like PoissonRect but fills all of the given rects. Points in
+// overlapping or neighboring rects still respect the spacing.
+func PoissonRects(rects []Rect, spacing float64) []Vec {
+	return globalRng.PoissonRects(rects, spacing)
+}
+
+// PoissonCircle is like PoissonRect but fills the circle c.
+func PoissonCircle(c Circle, spacing float64) []Vec {
+	return globalRng.PoissonCircle(c, spacing)
+}
+
+// PoissonPolygon is like PoissonRect but fills the polygon p.
+func PoissonPolygon(p Polygon, spacing float64) []Vec {
+	return globalRng.PoissonPolygon(p, spacing)
+}
+
+// ShuffleVecs returns a VecGen that returns each of points in order. Once they have all
+// been returned they are shuffled and returned again in the new order, and so on. This
+// turns the results of the Poisson functions into a VecGen that never repeats a point
+// until all of them are used, for example
+//
+//	system.InitPos = ShuffleVecs(PoissonRect(area, 20))
+//
+// points is modified by the shuffling. If it is empty then the zero vector is returned.
+func ShuffleVecs(points []Vec) VecGen {
+	return globalRng.ShuffleVecs(points)
+}
+
+// PoissonRect is the same as the package level PoissonRect but uses r's source.
+func (r *Rng) PoissonRect(rect Rect, spacing float64) []Vec {
+	return r.poisson(rect, spacing, []Rect{rect}, func(v Vec) bool {
+		return rect.CollidePoint(v.X, v.Y)
+	})
+}
+
+// PoissonRects is the same as the package level PoissonRects but uses r's source.
+func (r *Rng) PoissonRects(rects []Rect, spacing float64) []Vec {
+	if len(rects) == 0 {
+		return nil
+	}
+	bounds := rects[0]
+	for _, rect := range rects[1:] {
+		bounds.Union(rect)
+	}
+	return r.poisson(bounds, spacing, rects, func(v Vec) bool {
+		for _, rect := range rects {
+			if rect.CollidePoint(v.X, v.Y) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// PoissonCircle is the same as the package level PoissonCircle but uses r's source.
+func (r *Rng) PoissonCircle(c Circle, spacing float64) []Vec {
+	bounds := c.Bounds()
+	return r.poisson(bounds, spacing, []Rect{bounds}, func(v Vec) bool {
+		return c.CollidePoint(v.X, v.Y)
+	})
+}
+
+// PoissonPolygon is the same as the package level PoissonPolygon but uses r's source.
+func (r *Rng) PoissonPolygon(p Polygon, spacing float64) []Vec {
+	if len(p) < 3 {
+		return nil
+	}
+	bounds := p.Bounds()
+	return r.poisson(bounds, spacing, []Rect{bounds}, func(v Vec) bool {
+		return p.CollidePoint(v.X, v.Y)
+	})
+}
+
+// ShuffleVecs is the same as the package level ShuffleVecs but uses r's source.
+func (r *Rng) ShuffleVecs(points []Vec) VecGen {
+	if len(points) == 0 {
+		return func() Vec { return Vec{} }
+	}
+	i := 0
+	return func() Vec {
+		if i == len(points) {
+			r.shuffle(points)
+			i = 0
+		}
+		v := points[i]
+		i++
+		return v
+	}
+}
+
+func (r *Rng) shuffle(points []Vec) {
+	for i := len(points) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		points[i], points[j] = points[j], points[i]
+	}
+}
+
+// poisson fills bounds with points for which contains is true. It starts a new fill from
+// each of the seed rects in turn so that regions which aren't connected are all filled.
+func (r *Rng) poisson(bounds Rect, spacing float64, seeds []Rect, contains func(Vec) bool) []Vec {
+	if spacing <= 0 || bounds.W <= 0 || bounds.H <= 0 {
+		return nil
+	}
+
+	// Each cell is small enough to hold at most one point.
+	cell := spacing / math.Sqrt2
+	cols, rows := int(math.Ceil(bounds.W/cell)), int(math.Ceil(bounds.H/cell))
+	grid := make([]int, cols*rows)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(v Vec) (int, int) {
+		col, row := int((v.X-bounds.X)/cell), int((v.Y-bounds.Y)/cell)
+		return clampInt(col, 0, cols-1), clampInt(row, 0, rows-1)
+	}
+
+	points := []Vec{}
+	spacing2 := spacing * spacing
+	fits := func(v Vec) bool {
+		if !contains(v) {
+			return false
+		}
+		col, row := cellOf(v)
+		for y := maxInt(row-2, 0); y <= minInt(row+2, rows-1); y++ {
+			for x := maxInt(col-2, 0); x <= minInt(col+2, cols-1); x++ {
+				if i := grid[y*cols+x]; i >= 0 && points[i].Dist2(v) < spacing2 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	active := []int{}
+	add := func(v Vec) {
+		col, row := cellOf(v)
+		grid[row*cols+col] = len(points)
+		active = append(active, len(points))
+		points = append(points, v)
+	}
+
+	for _, seed := range seeds {
+		for i := 0; i < poissonTries; i++ {
+			if v := r.vecInRect(seed); fits(v) {
+				add(v)
+				break
+			}
+		}
+
+		for len(active) > 0 {
+			a := r.Intn(len(active))
+			p := points[active[a]]
+			found := false
+			for i := 0; i < poissonTries; i++ {
+				// Candidates are uniform in the annulus between spacing and 2*spacing.
+				v := p.Plus(r.RandVec().Times(r.circleRadius(spacing, 2*spacing)))
+				if fits(v) {
+					add(v)
+					found = true
+					break
+				}
+			}
+			if !found {
+				active[a] = active[len(active)-1]
+				active = active[:len(active)-1]
+			}
+		}
+	}
+	return points
+}
+
+func clampInt(n, min, max int) int {
+	return maxInt(min, minInt(n, max))
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package geo
+
+import "testing"
+
+func TestPoisson(t *testing.T) {
+	r := NewRngSeed(1)
+	rects := []Rect{{X: 0, Y: 0, W: 50, H: 50}, {X: 40, Y: 40, W: 50, H: 20}, {X: 200, Y: 0, W: 30, H: 30}}
+	circle := Circle{X: 10, Y: -20, R: 40}
+	poly := Polygon{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}, {X: 50, Y: 20}, {X: 0, Y: 100}}
+	cases := []struct {
+		name     string
+		points   []Vec
+		contains func(v Vec) bool
+		min      int
+	}{
+		{"rect", r.PoissonRect(rects[0], 5), func(v Vec) bool { return rects[0].CollidePoint(v.X, v.Y) }, 50},
+		{"rects", r.PoissonRects(rects, 5), func(v Vec) bool {
+			for _, rect := range rects {
+				if rect.CollidePoint(v.X, v.Y) {
+					return true
+				}
+			}
+			return false
+		}, 80},
+		{"circle", r.PoissonCircle(circle, 5), func(v Vec) bool { return circle.CollidePoint(v.X, v.Y) }, 100},
+		{"polygon", r.PoissonPolygon(poly, 5), func(v Vec) bool { return poly.CollidePoint(v.X, v.Y) }, 100},
+	}
+
+	for _, c := range cases {
+		if len(c.points) < c.min {
+			t.Errorf("%s: got %d points, want at least %d", c.name, len(c.points), c.min)
+		}
+		for i, p := range c.points {
+			if !c.contains(p) {
+				t.Errorf("%s: point %#v is outside", c.name, p)
+			}
+			for _, p2 := range c.points[i+1:] {
+				if p.Dist(p2) < 5 {
+					t.Errorf("%s: points %#v and %#v are too close", c.name, p, p2)
+				}
+			}
+		}
+	}
+
+	// Every disjoint rect gets filled.
+	far := 0
+	for _, p := range cases[1].points {
+		if rects[2].CollidePoint(p.X, p.Y) {
+			far++
+		}
+	}
+	if far < 10 {
+		t.Errorf("got %d points in the disjoint rect, want at least 10", far)
+	}
+
+	if got := r.PoissonRect(rects[0], 0); got != nil {
+		t.Errorf("spacing 0: got %v, want nil", got)
+	}
+}
+
+func TestPoissonDeterministic(t *testing.T) {
+	a := NewRngSeed(3).PoissonRect(Rect{W: 100, H: 100}, 7)
+	b := NewRngSeed(3).PoissonRect(Rect{W: 100, H: 100}, 7)
+	if len(a) != len(b) {
+		t.Fatalf("got %d and %d points", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("point %d: %#v != %#v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestShuffleVecs(t *testing.T) {
+	points := []Vec{{X: 1}, {X: 2}, {X: 3}, {X: 4}}
+	gen := NewRngSeed(1).ShuffleVecs(append([]Vec{}, points...))
+	for i, p := range points {
+		if got := gen(); got != p {
+			t.Errorf("first pass %d: got %#v, want %#v", i, got, p)
+		}
+	}
+	for pass := 0; pass < 3; pass++ {
+		seen := map[Vec]bool{}
+		for range points {
+			seen[gen()] = true
+		}
+		if len(seen) != len(points) {
+			t.Errorf("pass %d: got %d distinct points, want %d", pass, len(seen), len(points))
+		}
+	}
+
+	if got := ShuffleVecs(nil)(); got != (Vec{}) {
+		t.Errorf("empty: got %#v, want zero", got)
+	}
+}
@@ -0,0 +1,302 @@
+package geo
+
+import "math"
+
+// Polygon is a list of vertices connected in order, with the last connected back to the
+// first. The collision functions use the Separating Axis Theorem and so assume the Polygon
+// is convex, other functions work with any simple polygon. Winding order is described in
+// screen coordinates, where +y is down.
+type Polygon []Vec
+
+// RectPolygon returns a Polygon with the same corners as r, wound clockwise.
+func RectPolygon(r Rect) Polygon {
+	return Polygon{
+		{X: r.Left(), Y: r.Top()},
+		{X: r.Right(), Y: r.Top()},
+		{X: r.Right(), Y: r.Bottom()},
+		{X: r.Left(), Y: r.Bottom()},
+	}
+}
+
+// Copy returns a new Polygon with the same vertices.
+func (p Polygon) Copy() Polygon {
+	c := make(Polygon, len(p))
+	copy(c, p)
+	return c
+}
+
+// signedArea returns twice the signed area, positive for clockwise polygons.
+func (p Polygon) signedArea() float64 {
+	a := 0.0
+	for i := range p {
+		j := (i + 1) % len(p)
+		a += p[i].X*p[j].Y - p[j].X*p[i].Y
+	}
+	return a
+}
+
+// Area returns the area of the polygon.
+func (p Polygon) Area() float64 {
+	return math.Abs(p.signedArea()) / 2
+}
+
+// Centroid returns the center of mass of the polygon. If the polygon has no area then the
+// average of the vertices is returned instead.
+func (p Polygon) Centroid() Vec {
+	a := p.signedArea()
+	if a == 0 {
+		var sum Vec
+		for _, v := range p {
+			sum.Add(v)
+		}
+		if len(p) > 0 {
+			sum.Div(float64(len(p)))
+		}
+		return sum
+	}
+	var c Vec
+	for i := range p {
+		j := (i + 1) % len(p)
+		cross := p[i].X*p[j].Y - p[j].X*p[i].Y
+		c.X += (p[i].X + p[j].X) * cross
+		c.Y += (p[i].Y + p[j].Y) * cross
+	}
+	return c.DividedBy(3 * a)
+}
+
+// Clockwise returns true if the vertices are wound clockwise in screen coordinates.
+func (p Polygon) Clockwise() bool {
+	return p.signedArea() > 0
+}
+
+// Reverse reverses the winding order of the polygon, in place.
+func (p *Polygon) Reverse() {
+	s := *p
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Reversed returns a new Polygon with the opposite winding order.
+func (p Polygon) Reversed() Polygon {
+	r := p.Copy()
+	r.Reverse()
+	return r
+}
+
+// Bounds returns the smallest Rect that contains the polygon.
+func (p Polygon) Bounds() Rect {
+	if len(p) == 0 {
+		return Rect{}
+	}
+	minX, minY, maxX, maxY := p[0].X, p[0].Y, p[0].X, p[0].Y
+	for _, v := range p[1:] {
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+		minY, maxY = math.Min(minY, v.Y), math.Max(maxY, v.Y)
+	}
+	return Rect{X: minX, Y: minY, W: maxX - minX, H: maxY - minY}
+}
+
+// Move moves the Polygon by the given offset, in place.
+func (p *Polygon) Move(dx, dy float64) {
+	offset := Vec{X: dx, Y: dy}
+	for i := range *p {
+		(*p)[i].Add(offset)
+	}
+}
+
+// Moved returns a new Polygon moved by the given offset relative to this one.
+func (p Polygon) Moved(dx, dy float64) Polygon {
+	offset := Vec{X: dx, Y: dy}
+	m := make(Polygon, len(p))
+	for i, v := range p {
+		m[i] = v.Plus(offset)
+	}
+	return m
+}
+
+// Rotate rotates the Polygon (counterclockwise in screen coordinates) about the origin by
+// the given radians, in place.
+func (p *Polygon) Rotate(rad float64) {
+	for i := range *p {
+		(*p)[i].Rotate(rad)
+	}
+}
+
+// Rotated returns a new Polygon rotated (counterclockwise in screen coordinates) about the
+// origin by the given radians.
+func (p Polygon) Rotated(rad float64) Polygon {
+	r := make(Polygon, len(p))
+	for i, v := range p {
+		r[i] = v.Rotated(rad)
+	}
+	return r
+}
+
+// RotateAbout rotates the Polygon (counterclockwise in screen coordinates) about center by
+// the given radians, in place.
+func (p *Polygon) RotateAbout(center Vec, rad float64) {
+	for i, v := range *p {
+		(*p)[i] = v.Minus(center).Rotated(rad).Plus(center)
+	}
+}
+
+// RotatedAbout returns a new Polygon rotated (counterclockwise in screen coordinates) about
+// center by the given radians.
+func (p Polygon) RotatedAbout(center Vec, rad float64) Polygon {
+	r := make(Polygon, len(p))
+	for i, v := range p {
+		r[i] = v.Minus(center).Rotated(rad).Plus(center)
+	}
+	return r
+}
+
+// CollidePoint returns true if the point is within the Polygon.
+func (p Polygon) CollidePoint(x, y float64) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		if (p[i].Y > y) != (p[j].Y > y) &&
+			x < (p[j].X-p[i].X)*(y-p[i].Y)/(p[j].Y-p[i].Y)+p[i].X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ClosestPoint returns the point within the Polygon that is closest to v. If v is inside
+// the Polygon then v is returned.
+func (p Polygon) ClosestPoint(v Vec) Vec {
+	if len(p) == 0 || p.CollidePoint(v.X, v.Y) {
+		return v
+	}
+	closest := p[0]
+	for i := range p {
+		c := Segment{A: p[i], B: p[(i+1)%len(p)]}.ClosestPoint(v)
+		if c.Dist2(v) < closest.Dist2(v) {
+			closest = c
+		}
+	}
+	return closest
+}
+
+// Dist returns the distance from v to the closest point within the Polygon, or 0 if v is
+// inside.
+func (p Polygon) Dist(v Vec) float64 {
+	return v.Dist(p.ClosestPoint(v))
+}
+
+// CollidePolygon returns true if the Polygons overlap.
+func (p Polygon) CollidePolygon(other Polygon) bool {
+	depth, _ := p.PenetrationPolygon(other)
+	return depth > 0
+}
+
+// CollideRect returns true if the Polygon and Rect overlap.
+func (p Polygon) CollideRect(r Rect) bool {
+	depth, _ := p.PenetrationRect(r)
+	return depth > 0
+}
+
+// CollideCircle returns true if the Polygon and Circle overlap.
+func (p Polygon) CollideCircle(c Circle) bool {
+	depth, _ := p.PenetrationCircle(c)
+	return depth > 0
+}
+
+// PenetrationPolygon returns how far the Polygons overlap and the unit normal pointing from
+// p towards other. The minimum translation vector, the smallest movement of p that
+// separates them, is normal.Times(-depth). If they don't overlap, or either has fewer than
+// 3 vertices, then depth is 0 and normal is the zero vector.
+func (p Polygon) PenetrationPolygon(other Polygon) (depth float64, normal Vec) {
+	if len(p) < 3 || len(other) < 3 {
+		return 0, Vec{}
+	}
+	axes := append(p.axes(), other.axes()...)
+	return satPenetration(axes, p.project, other.project)
+}
+
+// PenetrationRect returns how far the Polygon and Rect overlap and the unit normal pointing
+// from p towards r. The minimum translation vector, the smallest movement of p that
+// separates them, is normal.Times(-depth). If they don't overlap then depth is 0 and normal
+// is the zero vector.
+func (p Polygon) PenetrationRect(r Rect) (depth float64, normal Vec) {
+	return p.PenetrationPolygon(RectPolygon(r))
+}
+
+// PenetrationCircle returns how far the Polygon and Circle overlap and the unit normal
+// pointing from p towards c. The minimum translation vector, the smallest movement of p
+// that separates them, is normal.Times(-depth). If they don't overlap, or p has fewer than
+// 3 vertices, then depth is 0 and normal is the zero vector.
+func (p Polygon) PenetrationCircle(c Circle) (depth float64, normal Vec) {
+	if len(p) < 3 {
+		return 0, Vec{}
+	}
+	center := c.Pos()
+	closest := p[0]
+	for _, v := range p[1:] {
+		if v.Dist2(center) < closest.Dist2(center) {
+			closest = v
+		}
+	}
+	axes := p.axes()
+	if axis := center.Minus(closest); axis.Len2() != 0 {
+		axes = append(axes, axis.Normalized())
+	}
+	return satPenetration(axes, p.project, func(axis Vec) (float64, float64) {
+		d := center.Dot(axis)
+		return d - c.R, d + c.R
+	})
+}
+
+// axes returns the unit normals of each edge.
+func (p Polygon) axes() []Vec {
+	axes := make([]Vec, 0, len(p))
+	for i := range p {
+		edge := p[(i+1)%len(p)].Minus(p[i])
+		if edge.Len2() == 0 {
+			continue
+		}
+		axes = append(axes, Vec{X: -edge.Y, Y: edge.X}.Normalized())
+	}
+	return axes
+}
+
+// project returns the interval covered by p when projected onto axis.
+func (p Polygon) project(axis Vec) (min, max float64) {
+	if len(p) == 0 {
+		return 0, 0
+	}
+	min = p[0].Dot(axis)
+	max = min
+	for _, v := range p[1:] {
+		d := v.Dot(axis)
+		min = math.Min(min, d)
+		max = math.Max(max, d)
+	}
+	return min, max
+}
+
+// satPenetration finds the axis of least overlap between two shapes given their projection
+// functions. The normal returned points from shape a to shape b.
+func satPenetration(axes []Vec, a, b func(axis Vec) (min, max float64)) (depth float64, normal Vec) {
+	if len(axes) == 0 {
+		return 0, Vec{}
+	}
+	depth = math.Inf(1)
+	for _, axis := range axes {
+		minA, maxA := a(axis)
+		minB, maxB := b(axis)
+		// Distance a would need to move along +axis or -axis to stop overlapping.
+		pos, neg := maxB-minA, maxA-minB
+		if pos <= 0 || neg <= 0 {
+			return 0, Vec{}
+		}
+		if pos < depth {
+			depth, normal = pos, axis.Times(-1)
+		}
+		if neg < depth {
+			depth, normal = neg, axis
+		}
+	}
+	return depth, normal
+}
@@ -0,0 +1,179 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+var unitSquare = Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+
+func TestPolygonArea(t *testing.T) {
+	cases := []struct {
+		p    Polygon
+		want float64
+	}{
+		{unitSquare, 1},
+		{unitSquare.Reversed(), 1},
+		{Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 3}}, 6},
+		{RectPolygon(Rect{X: 3, Y: 4, W: 5, H: 6}), 30},
+		{Polygon{}, 0},
+	}
+
+	for i, c := range cases {
+		got := c.p.Area()
+		if math.Abs(got-c.want) > e {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestPolygonCentroid(t *testing.T) {
+	cases := []struct {
+		p    Polygon
+		want Vec
+	}{
+		{unitSquare, Vec{X: 0.5, Y: 0.5}},
+		{unitSquare.Reversed(), Vec{X: 0.5, Y: 0.5}},
+		{Polygon{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 0, Y: 3}}, Vec{X: 1, Y: 1}},
+		{Polygon{{X: 0, Y: 0}, {X: 2, Y: 2}}, Vec{X: 1, Y: 1}},
+	}
+
+	for i, c := range cases {
+		got := c.p.Centroid()
+		if !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestPolygonClockwise(t *testing.T) {
+	if !unitSquare.Clockwise() {
+		t.Errorf("unitSquare should be clockwise")
+	}
+	if unitSquare.Reversed().Clockwise() {
+		t.Errorf("reversed unitSquare should not be clockwise")
+	}
+	p := unitSquare.Copy()
+	p.Reverse()
+	if p.Clockwise() || p[0] != unitSquare[3] {
+		t.Errorf("Reverse: got %#v", p)
+	}
+}
+
+func TestPolygonTransform(t *testing.T) {
+	got := unitSquare.Moved(2, 3)
+	want := Polygon{{X: 2, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 4}, {X: 2, Y: 4}}
+	p := unitSquare.Copy()
+	p.Move(2, 3)
+	for i := range want {
+		if got[i] != want[i] || p[i] != want[i] {
+			t.Errorf("Move vertex %d: got %#v and %#v, want %#v", i, got[i], p[i], want[i])
+		}
+	}
+
+	got = unitSquare.RotatedAbout(Vec{X: 0.5, Y: 0.5}, math.Pi/2)
+	want = Polygon{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}}
+	p = unitSquare.Copy()
+	p.RotateAbout(Vec{X: 0.5, Y: 0.5}, math.Pi/2)
+	for i := range want {
+		if !got[i].Equals(want[i], e) || !p[i].Equals(want[i], e) {
+			t.Errorf("RotateAbout vertex %d: got %#v and %#v, want %#v", i, got[i], p[i], want[i])
+		}
+	}
+
+	got = unitSquare.Rotated(math.Pi)
+	for i, v := range unitSquare {
+		if !got[i].Equals(v.Times(-1), e) {
+			t.Errorf("Rotated vertex %d: got %#v, want %#v", i, got[i], v.Times(-1))
+		}
+	}
+}
+
+func TestPolygonCollidePoint(t *testing.T) {
+	tri := Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}}
+	cases := []struct {
+		x, y float64
+		want bool
+	}{
+		{1, 1, true},
+		{3, 3, false},
+		{-1, 1, false},
+		{0.1, 3.8, true},
+	}
+
+	for i, c := range cases {
+		got := tri.CollidePoint(c.x, c.y)
+		if got != c.want {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestPolygonPenetrationPolygon(t *testing.T) {
+	diamond := Polygon{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+	cases := []struct {
+		p1, p2 Polygon
+		depth  float64
+		normal Vec
+	}{
+		{unitSquare, unitSquare.Moved(0.75, 0.1), 0.25, Vec{X: 1}},
+		{unitSquare, unitSquare.Moved(-0.1, -0.8), 0.2, Vec{Y: -1}},
+		{unitSquare, unitSquare.Moved(2, 0), 0, Vec{}},
+		{unitSquare, diamond.Moved(-0.9, 0.5), 0.1, Vec{X: -1}},
+		{unitSquare, diamond.Moved(-1.1, 0.5), 0, Vec{}},
+		{unitSquare, Polygon{}, 0, Vec{}},
+		{Polygon{{X: 0.5, Y: 0.5}}, unitSquare, 0, Vec{}},
+		{unitSquare, Polygon{{X: 0.2, Y: 0.5}, {X: 0.8, Y: 0.5}}, 0, Vec{}},
+	}
+
+	for i, c := range cases {
+		depth, normal := c.p1.PenetrationPolygon(c.p2)
+		if math.Abs(depth-c.depth) > e || !normal.Equals(c.normal, e) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, depth, normal, c.depth, c.normal)
+		}
+		if got := c.p1.CollidePolygon(c.p2); got != (c.depth > 0) {
+			t.Errorf("case %d: CollidePolygon got %v", i, got)
+		}
+		mtv := normal.Times(-depth - e)
+		if depth > 0 && c.p1.Moved(mtv.X, mtv.Y).CollidePolygon(c.p2) {
+			t.Errorf("case %d: not separated after resolving", i)
+		}
+	}
+}
+
+func TestPolygonPenetrationRect(t *testing.T) {
+	tri := Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}}
+	depth, normal := tri.PenetrationRect(Rect{X: 1, Y: -1, W: 1, H: 1.5})
+	if math.Abs(depth-0.5) > e || !normal.Equals(Vec{Y: -1}, e) {
+		t.Errorf("got %v %#v, want 0.5 (0, -1)", depth, normal)
+	}
+	if tri.CollideRect(Rect{X: 2.5, Y: 2.5, W: 1, H: 1}) {
+		t.Errorf("expected no collision past the hypotenuse")
+	}
+}
+
+func TestPolygonPenetrationCircle(t *testing.T) {
+	cases := []struct {
+		p      Polygon
+		c      Circle
+		depth  float64
+		normal Vec
+	}{
+		{unitSquare, Circle{X: 1.5, Y: 0.5, R: 1}, 0.5, Vec{X: 1}},
+		{unitSquare, Circle{X: 0.5, Y: -0.25, R: 0.5}, 0.25, Vec{Y: -1}},
+		{unitSquare, Circle{X: 1.5, Y: 1.5, R: 0.5}, 0, Vec{}},
+		{unitSquare, Circle{X: 1.5, Y: 1.5, R: 1}, 1 - math.Sqrt2/2, Vec{X: 1, Y: 1}.Normalized()},
+		{Polygon{{X: 0.5, Y: 0.5}}, Circle{X: 0.5, Y: 0.5, R: 1}, 0, Vec{}},
+	}
+
+	for i, c := range cases {
+		depth, normal := c.p.PenetrationCircle(c.c)
+		if math.Abs(depth-c.depth) > e || !normal.Equals(c.normal, e) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, depth, normal, c.depth, c.normal)
+		}
+		depth, normal = c.c.PenetrationPolygon(c.p)
+		if math.Abs(depth-c.depth) > e || !normal.Equals(c.normal.Times(-1), e) {
+			t.Errorf("reverse case %d: got %v %#v, want %v %#v", i, depth, normal, c.depth, c.normal.Times(-1))
+		}
+	}
+}
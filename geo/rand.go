@@ -0,0 +1,281 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+)
+
+// RandSource is a source of uniformly distributed random numbers in the range [0.0, 1.0).
+// *rand.Rand satisfies it.
+type RandSource interface {
+	Float64() float64
+}
+
+type globalSource struct{}
+
+func (globalSource) Float64() float64 {
+	return rand.Float64()
+}
+
+// globalRng is used by the package level generators.
+var globalRng = NewRng(globalSource{})
+
+// Rng makes the same random generators as the package level functions, such as RandNum and
+// RandVecCircle, except that they draw from the Rng's own source instead of the global one
+// in math/rand. Generators made from an Rng with a fixed seed produce the same sequence
+// every run, as long as they are called in the same order. An Rng is not safe for
+// concurrent use unless its source is.
+type Rng struct {
+	src RandSource
+}
+
+// NewRng returns an Rng that uses src for all its random numbers.
+func NewRng(src RandSource) *Rng {
+	return &Rng{src: src}
+}
+
+// NewRngSeed returns an Rng with a new math/rand source seeded with seed.
+func NewRngSeed(seed int64) *Rng {
+	return NewRng(rand.New(rand.NewSource(seed)))
+}
+
+// Float64 returns a uniform random number in the range [0.0, 1.0).
+func (r *Rng) Float64() float64 {
+	return r.src.Float64()
+}
+
+// Intn returns a uniform random integer in the range [0, n). It panics if n <= 0.
+func (r *Rng) Intn(n int) int {
+	if n <= 0 {
+		panic("geo: invalid argument to Intn")
+	}
+	i := int(r.src.Float64() * float64(n))
+	if i >= n {
+		// Guard against rounding up for very large n.
+		i = n - 1
+	}
+	return i
+}
+
+// RandNum is the same as the package level RandNum but uses r's source.
+func (r *Rng) RandNum(min, max float64) NumGen {
+	width := max - min
+	return func() float64 {
+		return r.src.Float64()*width + min
+	}
+}
+
+// RandRadius is the same as the package level RandRadius but uses r's source.
+func (r *Rng) RandRadius(minR, maxR float64) NumGen {
+	if maxR == 0 || maxR == minR {
+		return func() float64 {
+			return maxR
+		}
+	}
+	return func() float64 {
+		return r.circleRadius(minR, maxR)
+	}
+}
+
+// RandNormal is the same as the package level RandNormal but uses r's source.
+func (r *Rng) RandNormal(mean, stdDev float64) NumGen {
+	return func() float64 {
+		return r.normal()*stdDev + mean
+	}
+}
+
+// RandTriangular is the same as the package level RandTriangular but uses r's source.
+func (r *Rng) RandTriangular(min, mode, max float64) NumGen {
+	return func() float64 {
+		u := r.src.Float64()
+		if split := (mode - min) / (max - min); u < split {
+			return min + math.Sqrt(u*(max-min)*(mode-min))
+		}
+		return max - math.Sqrt((1-u)*(max-min)*(max-mode))
+	}
+}
+
+// RandExp is the same as the package level RandExp but uses r's source.
+func (r *Rng) RandExp(mean float64) NumGen {
+	return func() float64 {
+		return -math.Log(1-r.src.Float64()) * mean
+	}
+}
+
+// RandPoisson is the same as the package level RandPoisson but uses r's source.
+func (r *Rng) RandPoisson(mean float64) NumGen {
+	if mean > 30 {
+		// Counting takes too long for large means, but then the distribution is close
+		// enough to normal.
+		return func() float64 {
+			return math.Max(0, math.Round(r.normal()*math.Sqrt(mean)+mean))
+		}
+	}
+	limit := math.Exp(-mean)
+	return func() float64 {
+		n, p := 0.0, r.src.Float64()
+		for p > limit {
+			n++
+			p *= r.src.Float64()
+		}
+		return n
+	}
+}
+
+// RandWeighted is the same as the package level RandWeighted but uses r's source.
+func (r *Rng) RandWeighted(values, weights []float64) NumGen {
+	return func() float64 {
+		if len(values) == 0 || len(weights) == 0 {
+			return 0
+		}
+		return values[r.selectIndex(weights)]
+	}
+}
+
+// ChooseNum is the same as the package level ChooseNum but uses r's source.
+func (r *Rng) ChooseNum(gens ...NumGen) NumGen {
+	return func() float64 {
+		if len(gens) == 0 {
+			return 0
+		}
+		return gens[r.Intn(len(gens))]()
+	}
+}
+
+// RandVec is the same as the package level RandVec but uses r's source.
+func (r *Rng) RandVec() Vec {
+	rad := r.src.Float64() * 2 * math.Pi
+	return Vec{X: math.Cos(rad), Y: math.Sin(rad)}
+}
+
+// RandVecCircle is the same as the package level RandVecCircle but uses r's source.
+func (r *Rng) RandVecCircle(minRadius, maxRadius float64) VecGen {
+	return func() Vec {
+		return r.RandVec().Times(r.circleRadius(minRadius, maxRadius))
+	}
+}
+
+// RandVecArc is the same as the package level RandVecArc but uses r's source.
+func (r *Rng) RandVecArc(minRadius, maxRadius, minRadians, maxRadians float64) VecGen {
+	if maxRadians < minRadians {
+		minRadians, maxRadians = maxRadians, minRadians
+	}
+	return func() Vec {
+		radius := r.circleRadius(minRadius, maxRadius)
+		rad := r.src.Float64()*(maxRadians-minRadians) + minRadians
+		return Vec{X: radius}.Rotated(rad)
+	}
+}
+
+// RandVecRect is the same as the package level RandVecRect but uses r's source.
+func (r *Rng) RandVecRect(rect Rect) VecGen {
+	return func() Vec {
+		return r.vecInRect(rect)
+	}
+}
+
+// RandVecRects is the same as the package level RandVecRects but uses r's source.
+func (r *Rng) RandVecRects(rects []Rect) VecGen {
+	if len(rects) == 0 {
+		return func() Vec { return Vec{} }
+	}
+	areas := make([]float64, len(rects))
+	for i := range rects {
+		areas[i] = rects[i].Area()
+	}
+	return func() Vec {
+		return r.vecInRect(rects[r.selectIndex(areas)])
+	}
+}
+
+// RandVecSegment is the same as the package level RandVecSegment but uses r's source.
+func (r *Rng) RandVecSegment(s Segment) VecGen {
+	return func() Vec {
+		return s.A.Plus(s.B.Minus(s.A).Times(r.src.Float64()))
+	}
+}
+
+// RandVecCircleEdge is the same as the package level RandVecCircleEdge but uses r's
+// source.
+func (r *Rng) RandVecCircleEdge(radius float64) VecGen {
+	return func() Vec {
+		return r.RandVec().Times(radius)
+	}
+}
+
+// RandVecPolygon is the same as the package level RandVecPolygon but uses r's source.
+func (r *Rng) RandVecPolygon(p Polygon) VecGen {
+	tris := Triangulate(p)
+	areas := make([]float64, len(tris))
+	total := 0.0
+	for i := range tris {
+		areas[i] = tris[i].Area()
+		total += areas[i]
+	}
+	if total == 0 {
+		return func() Vec { return Vec{} }
+	}
+	return func() Vec {
+		t := tris[r.selectIndex(areas)]
+		// Reflect points in the other half of the parallelogram back into the triangle.
+		u, v := r.src.Float64(), r.src.Float64()
+		if u+v > 1 {
+			u, v = 1-u, 1-v
+		}
+		return t[0].Plus(t[1].Minus(t[0]).Times(u)).Plus(t[2].Minus(t[0]).Times(v))
+	}
+}
+
+// MixVec is the same as the package level MixVec but uses r's source.
+func (r *Rng) MixVec(gens []VecGen, weights []float64) VecGen {
+	return func() Vec {
+		if len(gens) == 0 || len(weights) == 0 {
+			return Vec{}
+		}
+		return gens[r.selectIndex(weights)]()
+	}
+}
+
+func (r *Rng) vecInRect(rect Rect) Vec {
+	return Vec{
+		X: r.src.Float64()*rect.W + rect.X,
+		Y: r.src.Float64()*rect.H + rect.Y,
+	}
+}
+
+// Returns a uniformaly distributed radius between minR and maxR.
+func (r *Rng) circleRadius(minR, maxR float64) float64 {
+	if maxR == 0 || maxR == minR {
+		return maxR
+	}
+	unitMin := minR / maxR
+	unitMin *= unitMin
+	return math.Sqrt(r.src.Float64()*(1-unitMin)+unitMin) * maxR
+}
+
+// normal returns a number from the standard normal distribution using the Box-Muller
+// transform.
+func (r *Rng) normal() float64 {
+	u := 1 - r.src.Float64()
+	return math.Sqrt(-2*math.Log(u)) * math.Cos(2*math.Pi*r.src.Float64())
+}
+
+// selectIndex returns a random index into weights where the chance of each index being
+// chosen is proportional to its weight. If all weights are 0 then each is equally likely.
+func (r *Rng) selectIndex(weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return r.Intn(len(weights))
+	}
+	n := r.src.Float64() * total
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	return len(weights) - 1
+}
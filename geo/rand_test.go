@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRngDeterministic(t *testing.T) {
+	rects := []Rect{{X: 0, Y: 0, W: 1, H: 1}, {X: 10, Y: 10, W: 5, H: 5}}
+	gens := func(r *Rng) (NumGen, NumGen, VecGen, VecGen, VecGen, VecGen) {
+		return r.RandNum(-5, 5), r.RandRadius(1, 2), r.RandVecCircle(1, 10),
+			r.RandVecArc(1, 10, 0, math.Pi), r.RandVecRect(rects[1]), r.RandVecRects(rects)
+	}
+	r1, r2 := NewRngSeed(42), NewRngSeed(42)
+	n1, rad1, c1, a1, rect1, rects1 := gens(r1)
+	n2, rad2, c2, a2, rect2, rects2 := gens(r2)
+	for i := 0; i < 100; i++ {
+		if n1() != n2() || rad1() != rad2() || r1.RandVec() != r2.RandVec() {
+			t.Fatalf("trial %d: NumGens differ", i)
+		}
+		if c1() != c2() || a1() != a2() || rect1() != rect2() || rects1() != rects2() {
+			t.Fatalf("trial %d: VecGens differ", i)
+		}
+	}
+
+	r3 := NewRngSeed(43)
+	if NewRngSeed(42).RandNum(0, 1)() == r3.RandNum(0, 1)() {
+		t.Errorf("different seeds gave the same number")
+	}
+}
+
+func TestRngRandVecRects(t *testing.T) {
+	r := NewRngSeed(1)
+	rects := []Rect{{X: 0, Y: 0, W: 1, H: 1}, {X: 10, Y: 0, W: 3, H: 1}, {X: 0, Y: 10, W: 0, H: 0}}
+	gen := r.RandVecRects(rects)
+	counts := make([]int, len(rects))
+	trials := 10000
+	for i := 0; i < trials; i++ {
+		v := gen()
+		for j, rect := range rects {
+			if rect.CollidePoint(v.X, v.Y) {
+				counts[j]++
+			}
+		}
+	}
+	if counts[2] != 0 {
+		t.Errorf("zero area rect was chosen %d times", counts[2])
+	}
+	if ratio := float64(counts[1]) / float64(counts[0]); math.Abs(ratio-3) > 0.3 {
+		t.Errorf("got ratio %v, want about 3", ratio)
+	}
+	if counts[0]+counts[1] != trials {
+		t.Errorf("some vectors were outside the rects: %v", counts)
+	}
+}
+
+func TestRngIntn(t *testing.T) {
+	r := NewRngSeed(1)
+	seen := make([]bool, 5)
+	for i := 0; i < 1000; i++ {
+		n := r.Intn(5)
+		if n < 0 || n >= 5 {
+			t.Fatalf("got %d, want [0, 5)", n)
+		}
+		seen[n] = true
+	}
+	for i, s := range seen {
+		if !s {
+			t.Errorf("%d never returned", i)
+		}
+	}
+}
+
+func TestRngDistributions(t *testing.T) {
+	r := NewRngSeed(1)
+	cases := []struct {
+		gen            NumGen
+		mean, variance float64
+		min, max       float64
+		whole          bool
+	}{
+		{r.RandNormal(5, 2), 5, 4, math.Inf(-1), math.Inf(1), false},
+		{r.RandTriangular(0, 1, 4), 5.0 / 3, 13.0 / 18, 0, 4, false},
+		{r.RandTriangular(-2, -2, 1), -1, 0.5, -2, 1, false},
+		{r.RandExp(3), 3, 9, 0, math.Inf(1), false},
+		{r.RandPoisson(4), 4, 4, 0, math.Inf(1), true},
+		{r.RandPoisson(100), 100, 100, 0, math.Inf(1), true},
+		{r.RandWeighted([]float64{1, 2, 10}, []float64{1, 3, 0}), 1.75, 0.1875, 1, 2, true},
+		{r.ChooseNum(ConstNum(1), r.RandNum(2, 4)), 2, 1.1666666666666667, 1, 4, false},
+	}
+
+	const trials = 100000
+	for i, c := range cases {
+		sum, sum2 := 0.0, 0.0
+		for j := 0; j < trials; j++ {
+			n := c.gen()
+			if n < c.min || n > c.max || c.whole && n != math.Round(n) {
+				t.Fatalf("case %d: got %v, want whole %v in [%v, %v]", i, n, c.whole, c.min, c.max)
+			}
+			sum += n
+			sum2 += n * n
+		}
+		mean := sum / trials
+		variance := sum2/trials - mean*mean
+		if math.Abs(mean-c.mean) > 0.02*math.Max(1, c.mean) {
+			t.Errorf("case %d: got mean %v, want %v", i, mean, c.mean)
+		}
+		if math.Abs(variance-c.variance) > 0.05*math.Max(1, c.variance) {
+			t.Errorf("case %d: got variance %v, want %v", i, variance, c.variance)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package geo
+
+import "math"
+
+// Ray is a half-line starting at Pos and extending forever in the direction Dir. Dir does
+// not need to be normalized but it must not be the zero vector.
+type Ray struct {
+	Pos, Dir Vec
+}
+
+// At returns the point that is dist along the ray.
+func (r Ray) At(dist float64) Vec {
+	return r.Pos.Plus(r.Dir.WithLen(dist))
+}
+
+// ClosestPoint returns the point on the ray that is closest to v.
+func (r Ray) ClosestPoint(v Vec) Vec {
+	d := r.Dir.Normalized()
+	return r.Pos.Plus(d.Times(math.Max(v.Minus(r.Pos).Dot(d), 0)))
+}
+
+// Dist returns the distance from v to the closest point on the ray.
+func (r Ray) Dist(v Vec) float64 {
+	return v.Dist(r.ClosestPoint(v))
+}
+
+// IntersectSegment returns the first point along the ray where it crosses s. A segment
+// parallel to the ray is never intersected.
+func (r Ray) IntersectSegment(s Segment) (Hit, bool) {
+	return r.hit(castSegment(r.Pos, r.Dir.Normalized(), math.Inf(1), s))
+}
+
+// IntersectRect returns the first point along the ray where it enters rect.
+func (r Ray) IntersectRect(rect Rect) (Hit, bool) {
+	return r.hit(castRect(r.Pos, r.Dir.Normalized(), math.Inf(1), rect))
+}
+
+// IntersectCircle returns the first point along the ray where it enters c.
+func (r Ray) IntersectCircle(c Circle) (Hit, bool) {
+	return r.hit(castCircle(r.Pos, r.Dir.Normalized(), math.Inf(1), c))
+}
+
+// IntersectPolygon returns the first point along the ray where it enters p.
+func (r Ray) IntersectPolygon(p Polygon) (Hit, bool) {
+	return r.hit(castPolygon(r.Pos, r.Dir.Normalized(), math.Inf(1), p))
+}
+
+// IntersectRectList returns the index of the Rect that the ray enters first and where it
+// does so, or -1 if it intersects none.
+func (r Ray) IntersectRectList(rects []Rect) (int, Hit) {
+	i, t, n := castRectList(r.Pos, r.Dir.Normalized(), math.Inf(1), rects)
+	if i < 0 {
+		return -1, Hit{}
+	}
+	hit, _ := r.hit(t, n, true)
+	return i, hit
+}
+
+func (r Ray) hit(t float64, normal Vec, ok bool) (Hit, bool) {
+	if !ok {
+		return Hit{}, false
+	}
+	// The direction is normalized before casting so t is the distance.
+	return Hit{Point: r.Pos.Plus(r.Dir.WithLen(t)), Normal: normal, Dist: t}, true
+}
+
+// Raycast casts a ray from pos in the direction dir and returns the index of the first
+// Rect it hits along with where it hits it, or -1 if it hits none. It is shorthand for
+//
+//	Ray{Pos: pos, Dir: dir}.IntersectRectList(rects)
+func Raycast(pos, dir Vec, rects []Rect) (int, Hit) {
+	return Ray{Pos: pos, Dir: dir}.IntersectRectList(rects)
+}
@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRayClosestPoint(t *testing.T) {
+	r := Ray{Pos: Vec{X: 1, Y: 1}, Dir: Vec{X: 2}}
+	cases := []struct {
+		v, want Vec
+	}{
+		{Vec{X: 100, Y: 5}, Vec{X: 100, Y: 1}},
+		{Vec{X: -5, Y: 5}, Vec{X: 1, Y: 1}},
+	}
+
+	for i, c := range cases {
+		got := r.ClosestPoint(c.v)
+		if !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+	if d := r.Dist(Vec{X: 100, Y: 5}); math.Abs(d-4) > e {
+		t.Errorf("got dist %v, want 4", d)
+	}
+}
+
+func TestRayIntersect(t *testing.T) {
+	r := Ray{Pos: Vec{X: 0, Y: 0}, Dir: Vec{X: 1, Y: 1}}
+	d := math.Sqrt2
+
+	hit, ok := r.IntersectRect(Rect{X: 3, Y: 2, W: 5, H: 5})
+	want := Hit{Point: Vec{X: 3, Y: 3}, Normal: Vec{X: -1}, Dist: 3 * d}
+	if !ok || !hitEquals(hit, want) {
+		t.Errorf("rect: got %v %#v, want %#v", ok, hit, want)
+	}
+
+	hit, ok = r.IntersectCircle(Circle{X: 10, Y: 10, R: d})
+	want = Hit{Point: Vec{X: 9, Y: 9}, Normal: Vec{X: -1, Y: -1}.Normalized(), Dist: 9 * d}
+	if !ok || !hitEquals(hit, want) {
+		t.Errorf("circle: got %v %#v, want %#v", ok, hit, want)
+	}
+
+	hit, ok = r.IntersectSegment(Segment{A: Vec{X: 0, Y: 6}, B: Vec{X: 6, Y: 0}})
+	want = Hit{Point: Vec{X: 3, Y: 3}, Normal: Vec{X: -1, Y: -1}.Normalized(), Dist: 3 * d}
+	if !ok || !hitEquals(hit, want) {
+		t.Errorf("segment: got %v %#v, want %#v", ok, hit, want)
+	}
+
+	if _, ok = r.IntersectRect(Rect{X: -5, Y: -5, W: 2, H: 2}); ok {
+		t.Errorf("rect behind ray was hit")
+	}
+	if _, ok = r.IntersectCircle(Circle{X: -5, Y: -5, R: 2}); ok {
+		t.Errorf("circle behind ray was hit")
+	}
+}
+
+func TestRaycast(t *testing.T) {
+	rects := []Rect{
+		{X: 10, Y: -1, W: 1, H: 2},
+		{X: 5, Y: 5, W: 1, H: 1},
+		{X: 4, Y: -1, W: 1, H: 2},
+		{X: -4, Y: -1, W: 1, H: 2},
+	}
+	i, hit := Raycast(Vec{}, Vec{X: 1}, rects)
+	want := Hit{Point: Vec{X: 4, Y: 0}, Normal: Vec{X: -1}, Dist: 4}
+	if i != 2 || !hitEquals(hit, want) {
+		t.Errorf("got %d %#v, want 2 %#v", i, hit, want)
+	}
+
+	i, _ = Raycast(Vec{}, Vec{Y: 1}, rects)
+	if i != -1 {
+		t.Errorf("got %d, want -1", i)
+	}
+
+	i, hit = Segment{A: Vec{X: 20, Y: 0}, B: Vec{X: 0, Y: 0}}.IntersectRectList(rects)
+	want = Hit{Point: Vec{X: 11, Y: 0}, Normal: Vec{X: 1}, Dist: 9}
+	if i != 0 || !hitEquals(hit, want) {
+		t.Errorf("segment: got %d %#v, want 0 %#v", i, hit, want)
+	}
+}
@@ -355,6 +355,23 @@ func (r Rect) CollideRect(other Rect) bool {
 	return r.X < other.Right() && r.Right() > other.X && r.Y < other.Bottom() && r.Bottom() > other.Y
 }
 
+// CollideCircle returns true if the Rect and Circle overlap.
+func (r Rect) CollideCircle(c Circle) bool {
+	return c.CollideRect(r)
+}
+
+// ClosestPoint returns the point within the Rect that is closest to v. If v is inside the
+// Rect then v is returned.
+func (r Rect) ClosestPoint(v Vec) Vec {
+	return Vec{X: clamp(v.X, r.Left(), r.Right()), Y: clamp(v.Y, r.Top(), r.Bottom())}
+}
+
+// Dist returns the distance from v to the closest point within the Rect, or 0 if v is
+// inside.
+func (r Rect) Dist(v Vec) float64 {
+	return v.Dist(r.ClosestPoint(v))
+}
+
 // CollideList returns the index of the first Rect this one collides with, or -1 if it
 // collides with none.
 func (r Rect) CollideList(others []Rect) int {
@@ -0,0 +1,218 @@
+package geo
+
+import "math"
+
+// Hit describes where a Segment or Ray intersects a shape.
+type Hit struct {
+	// Point is the position of the intersection.
+	Point Vec
+	// Normal is the unit surface normal of the shape at Point, facing the side that was
+	// hit. It is the zero vector if the Segment or Ray started inside the shape.
+	Normal Vec
+	// Dist is the distance from the start of the Segment or Ray to Point.
+	Dist float64
+}
+
+// Segment is the line segment between points A and B.
+type Segment struct {
+	A, B Vec
+}
+
+// Len returns the length of the segment.
+func (s Segment) Len() float64 {
+	return s.A.Dist(s.B)
+}
+
+// Mid returns the point halfway between A and B.
+func (s Segment) Mid() Vec {
+	return s.A.Plus(s.B).Times(0.5)
+}
+
+// Bounds returns the smallest Rect that contains the segment.
+func (s Segment) Bounds() Rect {
+	return Rect{X: s.A.X, Y: s.A.Y, W: s.B.X - s.A.X, H: s.B.Y - s.A.Y}.Normalized()
+}
+
+// Move moves the Segment by the given offset, in place.
+func (s *Segment) Move(dx, dy float64) {
+	offset := Vec{X: dx, Y: dy}
+	s.A.Add(offset)
+	s.B.Add(offset)
+}
+
+// Moved returns a new Segment moved by the given offset relative to this one.
+func (s Segment) Moved(dx, dy float64) Segment {
+	offset := Vec{X: dx, Y: dy}
+	return Segment{A: s.A.Plus(offset), B: s.B.Plus(offset)}
+}
+
+// ClosestPoint returns the point on the segment that is closest to v.
+func (s Segment) ClosestPoint(v Vec) Vec {
+	d := s.B.Minus(s.A)
+	l2 := d.Len2()
+	if l2 == 0 {
+		return s.A
+	}
+	t := clamp(v.Minus(s.A).Dot(d)/l2, 0, 1)
+	return s.A.Plus(d.Times(t))
+}
+
+// Dist returns the distance from v to the closest point on the segment.
+func (s Segment) Dist(v Vec) float64 {
+	return v.Dist(s.ClosestPoint(v))
+}
+
+// IntersectSegment returns the first point along s, starting from A, where it crosses
+// other. Parallel segments never intersect.
+func (s Segment) IntersectSegment(other Segment) (Hit, bool) {
+	return s.hit(castSegment(s.A, s.B.Minus(s.A), 1, other))
+}
+
+// IntersectRect returns the first point along s, starting from A, where it enters r.
+func (s Segment) IntersectRect(r Rect) (Hit, bool) {
+	return s.hit(castRect(s.A, s.B.Minus(s.A), 1, r))
+}
+
+// IntersectCircle returns the first point along s, starting from A, where it enters c.
+func (s Segment) IntersectCircle(c Circle) (Hit, bool) {
+	return s.hit(castCircle(s.A, s.B.Minus(s.A), 1, c))
+}
+
+// IntersectPolygon returns the first point along s, starting from A, where it enters p.
+func (s Segment) IntersectPolygon(p Polygon) (Hit, bool) {
+	return s.hit(castPolygon(s.A, s.B.Minus(s.A), 1, p))
+}
+
+// IntersectRectList returns the index of the Rect that s enters first and where it
+// does so, or -1 if it intersects none.
+func (s Segment) IntersectRectList(rects []Rect) (int, Hit) {
+	d := s.B.Minus(s.A)
+	i, t, n := castRectList(s.A, d, 1, rects)
+	if i < 0 {
+		return -1, Hit{}
+	}
+	hit, _ := s.hit(t, n, true)
+	return i, hit
+}
+
+func (s Segment) hit(t float64, normal Vec, ok bool) (Hit, bool) {
+	if !ok {
+		return Hit{}, false
+	}
+	d := s.B.Minus(s.A)
+	return Hit{Point: s.A.Plus(d.Times(t)), Normal: normal, Dist: t * d.Len()}, true
+}
+
+// cross returns the z component of the cross product of a and b.
+func cross(a, b Vec) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+// The cast functions find the smallest t in [0, tMax] where o + d*t touches the given
+// shape, along with the surface normal there. If o is inside the shape then t is 0 and
+// the normal is the zero vector.
+
+func castSegment(o, d Vec, tMax float64, seg Segment) (t float64, normal Vec, ok bool) {
+	e := seg.B.Minus(seg.A)
+	denom := cross(d, e)
+	if denom == 0 {
+		return 0, Vec{}, false
+	}
+	w := seg.A.Minus(o)
+	t = cross(w, e) / denom
+	u := cross(w, d) / denom
+	if t < 0 || t > tMax || u < 0 || u > 1 {
+		return 0, Vec{}, false
+	}
+	normal = Vec{X: -e.Y, Y: e.X}.Normalized()
+	if normal.Dot(d) > 0 {
+		normal.Mul(-1)
+	}
+	return t, normal, true
+}
+
+func castRect(o, d Vec, tMax float64, r Rect) (t float64, normal Vec, ok bool) {
+	tEnter, tExit := math.Inf(-1), math.Inf(1)
+	axes := [2]struct {
+		o, d, min, max float64
+		n              Vec
+	}{
+		{o.X, d.X, r.Left(), r.Right(), Vec{X: 1}},
+		{o.Y, d.Y, r.Top(), r.Bottom(), Vec{Y: 1}},
+	}
+	for _, a := range axes {
+		if a.d == 0 {
+			if a.o < a.min || a.o > a.max {
+				return 0, Vec{}, false
+			}
+			continue
+		}
+		t1, t2 := (a.min-a.o)/a.d, (a.max-a.o)/a.d
+		n := a.n.Times(-1)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			n = a.n
+		}
+		if t1 > tEnter {
+			tEnter, normal = t1, n
+		}
+		tExit = math.Min(tExit, t2)
+	}
+	if tEnter > tExit || tExit < 0 || tEnter > tMax {
+		return 0, Vec{}, false
+	}
+	if tEnter < 0 {
+		return 0, Vec{}, true
+	}
+	return tEnter, normal, true
+}
+
+func castCircle(o, d Vec, tMax float64, c Circle) (t float64, normal Vec, ok bool) {
+	m := o.Minus(c.Pos())
+	cc := m.Len2() - c.R*c.R
+	if cc < 0 {
+		return 0, Vec{}, true
+	}
+	a := d.Len2()
+	if a == 0 {
+		return 0, Vec{}, false
+	}
+	b := m.Dot(d)
+	disc := b*b - a*cc
+	if disc < 0 {
+		return 0, Vec{}, false
+	}
+	t = (-b - math.Sqrt(disc)) / a
+	if t < 0 || t > tMax {
+		return 0, Vec{}, false
+	}
+	return t, o.Plus(d.Times(t)).Minus(c.Pos()).Normalized(), true
+}
+
+func castPolygon(o, d Vec, tMax float64, p Polygon) (t float64, normal Vec, ok bool) {
+	if p.CollidePoint(o.X, o.Y) {
+		return 0, Vec{}, true
+	}
+	t = math.Inf(1)
+	for i := range p {
+		edgeT, n, hit := castSegment(o, d, tMax, Segment{A: p[i], B: p[(i+1)%len(p)]})
+		if hit && edgeT < t {
+			t, normal, ok = edgeT, n, true
+		}
+	}
+	if !ok {
+		return 0, Vec{}, false
+	}
+	return t, normal, true
+}
+
+func castRectList(o, d Vec, tMax float64, rects []Rect) (index int, t float64, normal Vec) {
+	index = -1
+	for i, r := range rects {
+		rt, n, hit := castRect(o, d, tMax, r)
+		if hit && (index < 0 || rt < t) {
+			index, t, normal = i, rt, n
+		}
+	}
+	return index, t, normal
+}
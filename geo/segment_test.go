@@ -0,0 +1,150 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSegmentClosestPoint(t *testing.T) {
+	s := Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 10, Y: 0}}
+	cases := []struct {
+		v, want Vec
+		dist    float64
+	}{
+		{Vec{X: 5, Y: 3}, Vec{X: 5, Y: 0}, 3},
+		{Vec{X: -3, Y: 4}, Vec{X: 0, Y: 0}, 5},
+		{Vec{X: 13, Y: -4}, Vec{X: 10, Y: 0}, 5},
+	}
+
+	for i, c := range cases {
+		got := s.ClosestPoint(c.v)
+		if !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+		if d := s.Dist(c.v); math.Abs(d-c.dist) > e {
+			t.Errorf("case %d: got dist %v, want %v", i, d, c.dist)
+		}
+	}
+}
+
+func TestShapeClosestPoint(t *testing.T) {
+	cases := []struct {
+		shape interface {
+			ClosestPoint(Vec) Vec
+			Dist(Vec) float64
+		}
+		v, want Vec
+		dist    float64
+	}{
+		{Rect{X: 0, Y: 0, W: 2, H: 2}, Vec{X: 1, Y: 1}, Vec{X: 1, Y: 1}, 0},
+		{Rect{X: 0, Y: 0, W: 2, H: 2}, Vec{X: 5, Y: 6}, Vec{X: 2, Y: 2}, 5},
+		{Circle{X: 0, Y: 0, R: 2}, Vec{X: 1, Y: 0}, Vec{X: 1, Y: 0}, 0},
+		{Circle{X: 0, Y: 0, R: 2}, Vec{X: 0, Y: -5}, Vec{X: 0, Y: -2}, 3},
+		{unitSquare, Vec{X: 0.5, Y: 0.5}, Vec{X: 0.5, Y: 0.5}, 0},
+		{unitSquare, Vec{X: 0.5, Y: 3}, Vec{X: 0.5, Y: 1}, 2},
+		{unitSquare, Vec{X: -3, Y: -4}, Vec{X: 0, Y: 0}, 5},
+	}
+
+	for i, c := range cases {
+		got := c.shape.ClosestPoint(c.v)
+		if !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+		if d := c.shape.Dist(c.v); math.Abs(d-c.dist) > e {
+			t.Errorf("case %d: got dist %v, want %v", i, d, c.dist)
+		}
+	}
+}
+
+func TestSegmentIntersectSegment(t *testing.T) {
+	cases := []struct {
+		s1, s2 Segment
+		ok     bool
+		want   Hit
+	}{
+		{
+			Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 10, Y: 0}},
+			Segment{A: Vec{X: 4, Y: -1}, B: Vec{X: 4, Y: 1}},
+			true, Hit{Point: Vec{X: 4, Y: 0}, Normal: Vec{X: -1}, Dist: 4},
+		},
+		{
+			Segment{A: Vec{X: 10, Y: 0}, B: Vec{X: 0, Y: 0}},
+			Segment{A: Vec{X: 4, Y: -1}, B: Vec{X: 4, Y: 1}},
+			true, Hit{Point: Vec{X: 4, Y: 0}, Normal: Vec{X: 1}, Dist: 6},
+		},
+		{
+			Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 3, Y: 0}},
+			Segment{A: Vec{X: 4, Y: -1}, B: Vec{X: 4, Y: 1}},
+			false, Hit{},
+		},
+		{
+			Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 10, Y: 0}},
+			Segment{A: Vec{X: 0, Y: 1}, B: Vec{X: 10, Y: 1}},
+			false, Hit{},
+		},
+	}
+
+	for i, c := range cases {
+		got, ok := c.s1.IntersectSegment(c.s2)
+		if ok != c.ok || !hitEquals(got, c.want) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, ok, got, c.ok, c.want)
+		}
+	}
+}
+
+func TestSegmentIntersectRect(t *testing.T) {
+	r := Rect{X: 2, Y: 2, W: 2, H: 2}
+	cases := []struct {
+		s    Segment
+		ok   bool
+		want Hit
+	}{
+		{Segment{A: Vec{X: 0, Y: 3}, B: Vec{X: 10, Y: 3}}, true, Hit{Point: Vec{X: 2, Y: 3}, Normal: Vec{X: -1}, Dist: 2}},
+		{Segment{A: Vec{X: 3, Y: 10}, B: Vec{X: 3, Y: 0}}, true, Hit{Point: Vec{X: 3, Y: 4}, Normal: Vec{Y: 1}, Dist: 6}},
+		{Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 1, Y: 1}}, false, Hit{}},
+		{Segment{A: Vec{X: 0, Y: 5}, B: Vec{X: 10, Y: 5}}, false, Hit{}},
+		{Segment{A: Vec{X: 3, Y: 3}, B: Vec{X: 10, Y: 3}}, true, Hit{Point: Vec{X: 3, Y: 3}}},
+	}
+
+	for i, c := range cases {
+		got, ok := c.s.IntersectRect(r)
+		if ok != c.ok || !hitEquals(got, c.want) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, ok, got, c.ok, c.want)
+		}
+	}
+}
+
+func TestSegmentIntersectCircle(t *testing.T) {
+	circle := Circle{X: 5, Y: 0, R: 2}
+	cases := []struct {
+		s    Segment
+		ok   bool
+		want Hit
+	}{
+		{Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 10, Y: 0}}, true, Hit{Point: Vec{X: 3, Y: 0}, Normal: Vec{X: -1}, Dist: 3}},
+		{Segment{A: Vec{X: 0, Y: 0}, B: Vec{X: 2, Y: 0}}, false, Hit{}},
+		{Segment{A: Vec{X: 0, Y: 3}, B: Vec{X: 10, Y: 3}}, false, Hit{}},
+		{Segment{A: Vec{X: 5, Y: 0}, B: Vec{X: 10, Y: 0}}, true, Hit{Point: Vec{X: 5, Y: 0}}},
+	}
+
+	for i, c := range cases {
+		got, ok := c.s.IntersectCircle(circle)
+		if ok != c.ok || !hitEquals(got, c.want) {
+			t.Errorf("case %d: got %v %#v, want %v %#v", i, ok, got, c.ok, c.want)
+		}
+	}
+}
+
+func TestSegmentIntersectPolygon(t *testing.T) {
+	tri := Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}}
+	s := Segment{A: Vec{X: 4, Y: 4}, B: Vec{X: 0, Y: 0}}
+	got, ok := s.IntersectPolygon(tri)
+	want := Hit{Point: Vec{X: 2, Y: 2}, Normal: Vec{X: 1, Y: 1}.Normalized(), Dist: 2 * math.Sqrt2}
+	if !ok || !hitEquals(got, want) {
+		t.Errorf("got %v %#v, want %#v", ok, got, want)
+	}
+}
+
+func hitEquals(h1, h2 Hit) bool {
+	return h1.Point.Equals(h2.Point, e) && h1.Normal.Equals(h2.Normal, e) && math.Abs(h1.Dist-h2.Dist) < e
+}
@@ -0,0 +1,486 @@
+package geo
+
+import "fmt"
+
+// NumSpec describes a NumGen in a form that can be saved as JSON, so that generators can be
+// tweaked without recompiling. Type is the name of the NumGen function with a lowercase
+// first letter, and the other fields are its arguments, with unused ones left out. For
+// example
+//
+//	{"type": "clampNum", "gen": {"type": "randNormal", "mean": 10, "stdDev": 2}, "min": 5, "max": 15}
+//
+// is the same as ClampNum(RandNormal(10, 2), 5, 15). The supported types and the fields
+// they use are:
+//
+//	constNum       value
+//	randNum        min, max
+//	randRadius     min, max
+//	randNormal     mean, stdDev
+//	randTriangular min, mode, max
+//	randExp        mean
+//	randPoisson    mean
+//	randWeighted   values, weights
+//	chooseNum      gens
+//	addNum         gens (exactly 2)
+//	sumNum         gens
+//	scaleNum       gen, factor
+//	clampNum       gen, min, max
+//	quantizeNum    gen, step
+//	cycleNum       values
+//	curveNum       gen, points
+//
+// MapNum can't be described since it takes a function.
+type NumSpec struct {
+	Type    string    `json:"type"`
+	Value   float64   `json:"value,omitempty"`
+	Min     float64   `json:"min,omitempty"`
+	Max     float64   `json:"max,omitempty"`
+	Mode    float64   `json:"mode,omitempty"`
+	Mean    float64   `json:"mean,omitempty"`
+	StdDev  float64   `json:"stdDev,omitempty"`
+	Factor  float64   `json:"factor,omitempty"`
+	Step    float64   `json:"step,omitempty"`
+	Values  []float64 `json:"values,omitempty"`
+	Weights []float64 `json:"weights,omitempty"`
+	Points  []Vec     `json:"points,omitempty"`
+	Gen     *NumSpec  `json:"gen,omitempty"`
+	Gens    []NumSpec `json:"gens,omitempty"`
+}
+
+// VecSpec describes a VecGen in a form that can be saved as JSON, the same way as NumSpec.
+// For example
+//
+//	{"type": "offsetVec", "gen": {"type": "randVecCircle", "maxRadius": 10}, "offset": {"type": "staticVec", "vec": {"X": 100, "Y": 50}}}
+//
+// is the same as OffsetVec(RandVecCircle(0, 10), StaticVec(Vec{X: 100, Y: 50})). The
+// supported types and the fields they use are:
+//
+//	staticVec         vec
+//	offsetVec         gen, offset
+//	randVecCircle     minRadius, maxRadius
+//	randVecArc        minRadius, maxRadius, minRadians, maxRadians
+//	randVecRect       rect
+//	randVecRects      rects
+//	randVecSegment    segment
+//	randVecCircleEdge radius
+//	randVecPolygon    polygon
+//	mixVec            gens, weights
+//	sumVec            gens
+//	rotateVec         gen, rad
+//	scaleVec          gen, factor
+//	transformVec      gen, transform
+//	mirrorVec         gen, axis
+//	cycleVec          points
+//	shuffleVecs       points
+//	gridVecs          rect, cols, rows, shuffle
+//	spiralVecs        n, spacing, shuffle
+//	fibonacciVecs     n, radius, shuffle
+//	poissonRect       rect, spacing, shuffle
+//	poissonRects      rects, spacing, shuffle
+//	poissonCircle     circle, spacing, shuffle
+//	poissonPolygon    polygon, spacing, shuffle
+//
+// The types that make a list of points return them with CycleVec, or ShuffleVecs if
+// shuffle is true. DynamicVec can't be described since it takes a pointer.
+type VecSpec struct {
+	Type       string    `json:"type"`
+	Vec        Vec       `json:"vec,omitzero"`
+	MinRadius  float64   `json:"minRadius,omitempty"`
+	MaxRadius  float64   `json:"maxRadius,omitempty"`
+	MinRadians float64   `json:"minRadians,omitempty"`
+	MaxRadians float64   `json:"maxRadians,omitempty"`
+	Radius     float64   `json:"radius,omitempty"`
+	Rect       Rect      `json:"rect,omitzero"`
+	Rects      []Rect    `json:"rects,omitempty"`
+	Segment    Segment   `json:"segment,omitzero"`
+	Circle     Circle    `json:"circle,omitzero"`
+	Polygon    Polygon   `json:"polygon,omitempty"`
+	Points     []Vec     `json:"points,omitempty"`
+	Transform  Transform `json:"transform,omitzero"`
+	Axis       Vec       `json:"axis,omitzero"`
+	Cols       int       `json:"cols,omitempty"`
+	Rows       int       `json:"rows,omitempty"`
+	N          int       `json:"n,omitempty"`
+	Spacing    float64   `json:"spacing,omitempty"`
+	Shuffle    bool      `json:"shuffle,omitempty"`
+	Weights    []float64 `json:"weights,omitempty"`
+	Rad        *NumSpec  `json:"rad,omitempty"`
+	Factor     *NumSpec  `json:"factor,omitempty"`
+	Gen        *VecSpec  `json:"gen,omitempty"`
+	Offset     *VecSpec  `json:"offset,omitempty"`
+	Gens       []VecSpec `json:"gens,omitempty"`
+}
+
+// BuildNum returns the NumGen described by s, or an error saying what is wrong with it.
+func BuildNum(s NumSpec) (NumGen, error) {
+	return globalRng.BuildNum(s)
+}
+
+// BuildVec returns the VecGen described by s, or an error saying what is wrong with it.
+func BuildVec(s VecSpec) (VecGen, error) {
+	return globalRng.BuildVec(s)
+}
+
+// BuildNum is the same as the package level BuildNum but uses r's source.
+func (r *Rng) BuildNum(s NumSpec) (NumGen, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return r.buildNum(s), nil
+}
+
+// BuildVec is the same as the package level BuildVec but uses r's source.
+func (r *Rng) BuildVec(s VecSpec) (VecGen, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return r.buildVec(s)
+}
+
+// specCheck collects the first problem found with a spec.
+type specCheck struct {
+	typ string
+	err error
+}
+
+func (c *specCheck) fail(format string, args ...interface{}) {
+	if c.err == nil {
+		c.err = fmt.Errorf("%s: %s", c.typ, fmt.Sprintf(format, args...))
+	}
+}
+
+func (c *specCheck) ordered(minName string, min float64, maxName string, max float64) {
+	if min > max {
+		c.fail("%s %v is greater than %s %v", minName, min, maxName, max)
+	}
+}
+
+func (c *specCheck) positive(name string, n float64) {
+	if !(n > 0) {
+		c.fail("%s must be positive, got %v", name, n)
+	}
+}
+
+func (c *specCheck) nonNegative(name string, n float64) {
+	if !(n >= 0) {
+		c.fail("%s must not be negative, got %v", name, n)
+	}
+}
+
+func (c *specCheck) notEmpty(name string, n int) {
+	if n == 0 {
+		c.fail("%s must not be empty", name)
+	}
+}
+
+func (c *specCheck) weights(n int, weights []float64) {
+	if len(weights) != n {
+		c.fail("got %d weights for %d choices", len(weights), n)
+	}
+	for i, w := range weights {
+		c.nonNegative(fmt.Sprintf("weights[%d]", i), w)
+	}
+}
+
+// sub records the error from checking a nested spec, prefixed by where it is.
+func (c *specCheck) sub(name string, err error) {
+	if err != nil && c.err == nil {
+		c.err = fmt.Errorf("%s: %s: %v", c.typ, name, err)
+	}
+}
+
+func (c *specCheck) num(name string, s *NumSpec) {
+	if s == nil {
+		c.fail("%s is missing", name)
+		return
+	}
+	c.sub(name, s.Validate())
+}
+
+func (c *specCheck) vec(name string, s *VecSpec) {
+	if s == nil {
+		c.fail("%s is missing", name)
+		return
+	}
+	c.sub(name, s.Validate())
+}
+
+// Validate returns an error saying what is wrong with s, or nil if it can be built.
+func (s NumSpec) Validate() error {
+	c := &specCheck{typ: s.Type}
+	switch s.Type {
+	case "constNum":
+	case "randNum":
+		c.ordered("min", s.Min, "max", s.Max)
+	case "randRadius":
+		c.nonNegative("min", s.Min)
+		c.ordered("min", s.Min, "max", s.Max)
+	case "randNormal":
+		c.nonNegative("stdDev", s.StdDev)
+	case "randTriangular":
+		c.ordered("min", s.Min, "mode", s.Mode)
+		c.ordered("mode", s.Mode, "max", s.Max)
+		if s.Min == s.Max {
+			c.fail("min and max must be different")
+		}
+	case "randExp", "randPoisson":
+		c.nonNegative("mean", s.Mean)
+	case "randWeighted":
+		c.notEmpty("values", len(s.Values))
+		c.weights(len(s.Values), s.Weights)
+	case "chooseNum", "sumNum":
+		if s.Type == "chooseNum" {
+			c.notEmpty("gens", len(s.Gens))
+		}
+		for i := range s.Gens {
+			c.num(fmt.Sprintf("gens[%d]", i), &s.Gens[i])
+		}
+	case "addNum":
+		if len(s.Gens) != 2 {
+			c.fail("needs 2 gens, got %d", len(s.Gens))
+		}
+		for i := range s.Gens {
+			c.num(fmt.Sprintf("gens[%d]", i), &s.Gens[i])
+		}
+	case "scaleNum":
+		c.num("gen", s.Gen)
+	case "clampNum":
+		c.num("gen", s.Gen)
+		c.ordered("min", s.Min, "max", s.Max)
+	case "quantizeNum":
+		c.num("gen", s.Gen)
+		c.positive("step", s.Step)
+	case "cycleNum":
+		c.notEmpty("values", len(s.Values))
+	case "curveNum":
+		c.num("gen", s.Gen)
+		c.notEmpty("points", len(s.Points))
+		for i := 1; i < len(s.Points); i++ {
+			if s.Points[i].X <= s.Points[i-1].X {
+				c.fail("points must be sorted by increasing X, but points[%d] is not after points[%d]", i, i-1)
+			}
+		}
+	case "":
+		return fmt.Errorf("type is missing")
+	default:
+		return fmt.Errorf("unknown NumGen type %q", s.Type)
+	}
+	return c.err
+}
+
+// Validate returns an error saying what is wrong with s, or nil if it can be built.
+func (s VecSpec) Validate() error {
+	c := &specCheck{typ: s.Type}
+	switch s.Type {
+	case "staticVec":
+	case "offsetVec":
+		c.vec("gen", s.Gen)
+		c.vec("offset", s.Offset)
+	case "randVecCircle", "randVecArc":
+		c.nonNegative("minRadius", s.MinRadius)
+		c.ordered("minRadius", s.MinRadius, "maxRadius", s.MaxRadius)
+	case "randVecRect":
+	case "randVecRects":
+		c.notEmpty("rects", len(s.Rects))
+	case "randVecSegment":
+	case "randVecCircleEdge":
+		c.nonNegative("radius", s.Radius)
+	case "randVecPolygon":
+		if len(s.Polygon) < 3 {
+			c.fail("polygon needs at least 3 vertices, got %d", len(s.Polygon))
+		}
+	case "mixVec", "sumVec":
+		if s.Type == "mixVec" {
+			c.notEmpty("gens", len(s.Gens))
+			c.weights(len(s.Gens), s.Weights)
+		}
+		for i := range s.Gens {
+			c.vec(fmt.Sprintf("gens[%d]", i), &s.Gens[i])
+		}
+	case "rotateVec":
+		c.vec("gen", s.Gen)
+		c.num("rad", s.Rad)
+	case "scaleVec":
+		c.vec("gen", s.Gen)
+		c.num("factor", s.Factor)
+	case "transformVec":
+		c.vec("gen", s.Gen)
+		if s.Transform == (Transform{}) {
+			c.fail("transform is missing")
+		}
+	case "mirrorVec":
+		c.vec("gen", s.Gen)
+		if s.Axis == (Vec{}) {
+			c.fail("axis is missing")
+		}
+	case "cycleVec", "shuffleVecs":
+		c.notEmpty("points", len(s.Points))
+	case "gridVecs":
+		c.positive("cols", float64(s.Cols))
+		c.positive("rows", float64(s.Rows))
+	case "spiralVecs":
+		c.positive("n", float64(s.N))
+		c.positive("spacing", s.Spacing)
+	case "fibonacciVecs":
+		c.positive("n", float64(s.N))
+		c.nonNegative("radius", s.Radius)
+	case "poissonRect":
+		c.positive("spacing", s.Spacing)
+		c.positive("rect width", s.Rect.W)
+		c.positive("rect height", s.Rect.H)
+	case "poissonRects":
+		c.positive("spacing", s.Spacing)
+		c.notEmpty("rects", len(s.Rects))
+	case "poissonCircle":
+		c.positive("spacing", s.Spacing)
+		c.positive("circle radius", s.Circle.R)
+	case "poissonPolygon":
+		c.positive("spacing", s.Spacing)
+		if len(s.Polygon) < 3 {
+			c.fail("polygon needs at least 3 vertices, got %d", len(s.Polygon))
+		}
+	case "":
+		return fmt.Errorf("type is missing")
+	default:
+		return fmt.Errorf("unknown VecGen type %q", s.Type)
+	}
+	return c.err
+}
+
+// buildNum returns the NumGen for s, which must be valid.
+func (r *Rng) buildNum(s NumSpec) NumGen {
+	gens := make([]NumGen, len(s.Gens))
+	for i := range s.Gens {
+		gens[i] = r.buildNum(s.Gens[i])
+	}
+	var gen NumGen
+	if s.Gen != nil {
+		gen = r.buildNum(*s.Gen)
+	}
+
+	switch s.Type {
+	case "randNum":
+		return r.RandNum(s.Min, s.Max)
+	case "randRadius":
+		return r.RandRadius(s.Min, s.Max)
+	case "randNormal":
+		return r.RandNormal(s.Mean, s.StdDev)
+	case "randTriangular":
+		return r.RandTriangular(s.Min, s.Mode, s.Max)
+	case "randExp":
+		return r.RandExp(s.Mean)
+	case "randPoisson":
+		return r.RandPoisson(s.Mean)
+	case "randWeighted":
+		return r.RandWeighted(s.Values, s.Weights)
+	case "chooseNum":
+		return r.ChooseNum(gens...)
+	case "addNum":
+		return AddNum(gens[0], gens[1])
+	case "sumNum":
+		return SumNum(gens...)
+	case "scaleNum":
+		return ScaleNum(gen, s.Factor)
+	case "clampNum":
+		return ClampNum(gen, s.Min, s.Max)
+	case "quantizeNum":
+		return QuantizeNum(gen, s.Step)
+	case "cycleNum":
+		return CycleNum(s.Values...)
+	case "curveNum":
+		return CurveNum(gen, s.Points)
+	}
+	return ConstNum(s.Value)
+}
+
+// buildVec returns the VecGen for s, which must be valid. It returns an error if a list of
+// points turns out to be empty, such as when the shape is smaller than the spacing.
+func (r *Rng) buildVec(s VecSpec) (VecGen, error) {
+	gens := make([]VecGen, len(s.Gens))
+	for i := range s.Gens {
+		g, err := r.buildVec(s.Gens[i])
+		if err != nil {
+			return nil, err
+		}
+		gens[i] = g
+	}
+	var gen VecGen
+	if s.Gen != nil {
+		g, err := r.buildVec(*s.Gen)
+		if err != nil {
+			return nil, err
+		}
+		gen = g
+	}
+
+	switch s.Type {
+	case "offsetVec":
+		offset, err := r.buildVec(*s.Offset)
+		if err != nil {
+			return nil, err
+		}
+		return OffsetVec(gen, offset), nil
+	case "randVecCircle":
+		return r.RandVecCircle(s.MinRadius, s.MaxRadius), nil
+	case "randVecArc":
+		return r.RandVecArc(s.MinRadius, s.MaxRadius, s.MinRadians, s.MaxRadians), nil
+	case "randVecRect":
+		return r.RandVecRect(s.Rect), nil
+	case "randVecRects":
+		return r.RandVecRects(s.Rects), nil
+	case "randVecSegment":
+		return r.RandVecSegment(s.Segment), nil
+	case "randVecCircleEdge":
+		return r.RandVecCircleEdge(s.Radius), nil
+	case "randVecPolygon":
+		return r.RandVecPolygon(s.Polygon), nil
+	case "mixVec":
+		return r.MixVec(gens, s.Weights), nil
+	case "sumVec":
+		return SumVec(gens...), nil
+	case "rotateVec":
+		return RotateVec(gen, r.buildNum(*s.Rad)), nil
+	case "scaleVec":
+		return ScaleVec(gen, r.buildNum(*s.Factor)), nil
+	case "transformVec":
+		return TransformVec(gen, s.Transform), nil
+	case "mirrorVec":
+		return MirrorVec(gen, s.Axis), nil
+	case "cycleVec":
+		return CycleVec(s.Points...), nil
+	case "shuffleVecs":
+		// Copy the points so shuffling doesn't change the spec.
+		return r.ShuffleVecs(append([]Vec{}, s.Points...)), nil
+	case "gridVecs", "spiralVecs", "fibonacciVecs", "poissonRect", "poissonRects", "poissonCircle", "poissonPolygon":
+		points := r.pointList(s)
+		if len(points) == 0 {
+			return nil, fmt.Errorf("%s: no points fit with a spacing of %v", s.Type, s.Spacing)
+		}
+		if s.Shuffle {
+			return r.ShuffleVecs(points), nil
+		}
+		return CycleVec(points...), nil
+	}
+	return StaticVec(s.Vec), nil
+}
+
+// pointList returns the points for the types of VecSpec that make a list of them.
+func (r *Rng) pointList(s VecSpec) []Vec {
+	switch s.Type {
+	case "gridVecs":
+		return GridVecs(s.Rect, s.Cols, s.Rows)
+	case "spiralVecs":
+		return SpiralVecs(s.N, s.Spacing)
+	case "fibonacciVecs":
+		return FibonacciVecs(s.N, s.Radius)
+	case "poissonRect":
+		return r.PoissonRect(s.Rect, s.Spacing)
+	case "poissonRects":
+		return r.PoissonRects(s.Rects, s.Spacing)
+	case "poissonCircle":
+		return r.PoissonCircle(s.Circle, s.Spacing)
+	case "poissonPolygon":
+		return r.PoissonPolygon(s.Polygon, s.Spacing)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package geo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBuildNum(t *testing.T) {
+	gen := &NumSpec{Type: "randNum", Min: 1, Max: 2}
+	cases := []struct {
+		spec NumSpec
+		want func(r *Rng) NumGen
+	}{
+		{NumSpec{Type: "constNum", Value: 3}, func(r *Rng) NumGen { return ConstNum(3) }},
+		{NumSpec{Type: "randNum", Min: -1, Max: 2}, func(r *Rng) NumGen { return r.RandNum(-1, 2) }},
+		{NumSpec{Type: "randRadius", Min: 1, Max: 2}, func(r *Rng) NumGen { return r.RandRadius(1, 2) }},
+		{NumSpec{Type: "randNormal", Mean: 1, StdDev: 2}, func(r *Rng) NumGen { return r.RandNormal(1, 2) }},
+		{NumSpec{Type: "randTriangular", Min: 1, Mode: 2, Max: 5},
+			func(r *Rng) NumGen { return r.RandTriangular(1, 2, 5) }},
+		{NumSpec{Type: "randExp", Mean: 3}, func(r *Rng) NumGen { return r.RandExp(3) }},
+		{NumSpec{Type: "randPoisson", Mean: 3}, func(r *Rng) NumGen { return r.RandPoisson(3) }},
+		{NumSpec{Type: "randWeighted", Values: []float64{1, 5}, Weights: []float64{1, 2}},
+			func(r *Rng) NumGen { return r.RandWeighted([]float64{1, 5}, []float64{1, 2}) }},
+		{NumSpec{Type: "chooseNum", Gens: []NumSpec{{Type: "constNum", Value: 1}, *gen}},
+			func(r *Rng) NumGen { return r.ChooseNum(ConstNum(1), r.RandNum(1, 2)) }},
+		{NumSpec{Type: "addNum", Gens: []NumSpec{*gen, {Type: "constNum", Value: 1}}},
+			func(r *Rng) NumGen { return AddNum(r.RandNum(1, 2), ConstNum(1)) }},
+		{NumSpec{Type: "sumNum", Gens: []NumSpec{*gen, *gen}},
+			func(r *Rng) NumGen { return SumNum(r.RandNum(1, 2), r.RandNum(1, 2)) }},
+		{NumSpec{Type: "scaleNum", Gen: gen, Factor: 3},
+			func(r *Rng) NumGen { return ScaleNum(r.RandNum(1, 2), 3) }},
+		{NumSpec{Type: "clampNum", Gen: gen, Min: 1.2, Max: 1.8},
+			func(r *Rng) NumGen { return ClampNum(r.RandNum(1, 2), 1.2, 1.8) }},
+		{NumSpec{Type: "quantizeNum", Gen: gen, Step: 0.25},
+			func(r *Rng) NumGen { return QuantizeNum(r.RandNum(1, 2), 0.25) }},
+		{NumSpec{Type: "cycleNum", Values: []float64{1, 2, 3}}, func(r *Rng) NumGen { return CycleNum(1, 2, 3) }},
+		{NumSpec{Type: "curveNum", Gen: gen, Points: []Vec{{X: 1, Y: 0}, {X: 2, Y: 10}}},
+			func(r *Rng) NumGen { return CurveNum(r.RandNum(1, 2), []Vec{{X: 1, Y: 0}, {X: 2, Y: 10}}) }},
+	}
+
+	for i, c := range cases {
+		got, err := NewRngSeed(1).BuildNum(c.spec)
+		if err != nil {
+			t.Errorf("case %d: %v", i, err)
+			continue
+		}
+		want := c.want(NewRngSeed(1))
+		for j := 0; j < 20; j++ {
+			if g, w := got(), want(); g != w {
+				t.Errorf("case %d call %d: got %v, want %v", i, j, g, w)
+				break
+			}
+		}
+	}
+}
+
+func TestBuildVec(t *testing.T) {
+	gen := &VecSpec{Type: "randVecCircle", MinRadius: 1, MaxRadius: 2}
+	tri := Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}}
+	rects := []Rect{{W: 10, H: 10}, {X: 20, W: 10, H: 10}}
+	cases := []struct {
+		spec VecSpec
+		want func(r *Rng) VecGen
+	}{
+		{VecSpec{Type: "staticVec", Vec: Vec{X: 1, Y: 2}}, func(r *Rng) VecGen { return StaticVec(Vec{X: 1, Y: 2}) }},
+		{VecSpec{Type: "offsetVec", Gen: gen, Offset: &VecSpec{Type: "staticVec", Vec: Vec{X: 5}}},
+			func(r *Rng) VecGen { return OffsetVec(r.RandVecCircle(1, 2), StaticVec(Vec{X: 5})) }},
+		{*gen, func(r *Rng) VecGen { return r.RandVecCircle(1, 2) }},
+		{VecSpec{Type: "randVecArc", MinRadius: 1, MaxRadius: 2, MinRadians: 0.5, MaxRadians: 1},
+			func(r *Rng) VecGen { return r.RandVecArc(1, 2, 0.5, 1) }},
+		{VecSpec{Type: "randVecRect", Rect: rects[1]}, func(r *Rng) VecGen { return r.RandVecRect(rects[1]) }},
+		{VecSpec{Type: "randVecRects", Rects: rects}, func(r *Rng) VecGen { return r.RandVecRects(rects) }},
+		{VecSpec{Type: "randVecSegment", Segment: Segment{B: Vec{X: 3, Y: 4}}},
+			func(r *Rng) VecGen { return r.RandVecSegment(Segment{B: Vec{X: 3, Y: 4}}) }},
+		{VecSpec{Type: "randVecCircleEdge", Radius: 3}, func(r *Rng) VecGen { return r.RandVecCircleEdge(3) }},
+		{VecSpec{Type: "randVecPolygon", Polygon: tri}, func(r *Rng) VecGen { return r.RandVecPolygon(tri) }},
+		{VecSpec{Type: "mixVec", Gens: []VecSpec{*gen, {Type: "staticVec"}}, Weights: []float64{1, 1}},
+			func(r *Rng) VecGen {
+				return r.MixVec([]VecGen{r.RandVecCircle(1, 2), StaticVec(Vec{})}, []float64{1, 1})
+			}},
+		{VecSpec{Type: "sumVec", Gens: []VecSpec{*gen, *gen}},
+			func(r *Rng) VecGen { return SumVec(r.RandVecCircle(1, 2), r.RandVecCircle(1, 2)) }},
+		{VecSpec{Type: "rotateVec", Gen: gen, Rad: &NumSpec{Type: "randNum", Max: 1}},
+			func(r *Rng) VecGen { return RotateVec(r.RandVecCircle(1, 2), r.RandNum(0, 1)) }},
+		{VecSpec{Type: "scaleVec", Gen: gen, Factor: &NumSpec{Type: "constNum", Value: 2}},
+			func(r *Rng) VecGen { return ScaleVec(r.RandVecCircle(1, 2), ConstNum(2)) }},
+		{VecSpec{Type: "transformVec", Gen: gen, Transform: ScaleTransform(1, 2)},
+			func(r *Rng) VecGen { return TransformVec(r.RandVecCircle(1, 2), ScaleTransform(1, 2)) }},
+		{VecSpec{Type: "mirrorVec", Gen: gen, Axis: Vec{X: 1}},
+			func(r *Rng) VecGen { return MirrorVec(r.RandVecCircle(1, 2), Vec{X: 1}) }},
+		{VecSpec{Type: "cycleVec", Points: tri}, func(r *Rng) VecGen { return CycleVec(tri...) }},
+		{VecSpec{Type: "shuffleVecs", Points: tri},
+			func(r *Rng) VecGen { return r.ShuffleVecs(append([]Vec{}, tri...)) }},
+		{VecSpec{Type: "gridVecs", Rect: rects[0], Cols: 2, Rows: 3},
+			func(r *Rng) VecGen { return CycleVec(GridVecs(rects[0], 2, 3)...) }},
+		{VecSpec{Type: "spiralVecs", N: 10, Spacing: 2, Shuffle: true},
+			func(r *Rng) VecGen { return r.ShuffleVecs(SpiralVecs(10, 2)) }},
+		{VecSpec{Type: "fibonacciVecs", N: 10, Radius: 2},
+			func(r *Rng) VecGen { return CycleVec(FibonacciVecs(10, 2)...) }},
+		{VecSpec{Type: "poissonRect", Rect: rects[0], Spacing: 2},
+			func(r *Rng) VecGen { return CycleVec(r.PoissonRect(rects[0], 2)...) }},
+		{VecSpec{Type: "poissonRects", Rects: rects, Spacing: 2, Shuffle: true},
+			func(r *Rng) VecGen { return r.ShuffleVecs(r.PoissonRects(rects, 2)) }},
+		{VecSpec{Type: "poissonCircle", Circle: Circle{X: 1, R: 5}, Spacing: 2},
+			func(r *Rng) VecGen { return CycleVec(r.PoissonCircle(Circle{X: 1, R: 5}, 2)...) }},
+		{VecSpec{Type: "poissonPolygon", Polygon: tri, Spacing: 2},
+			func(r *Rng) VecGen { return CycleVec(r.PoissonPolygon(tri, 2)...) }},
+	}
+
+	for i, c := range cases {
+		got, err := NewRngSeed(1).BuildVec(c.spec)
+		if err != nil {
+			t.Errorf("case %d: %v", i, err)
+			continue
+		}
+		want := c.want(NewRngSeed(1))
+		for j := 0; j < 20; j++ {
+			if g, w := got(), want(); g != w {
+				t.Errorf("case %d call %d: got %#v, want %#v", i, j, g, w)
+				break
+			}
+		}
+	}
+
+	// Shuffling doesn't change the spec.
+	spec := VecSpec{Type: "shuffleVecs", Points: tri.Copy()}
+	g, _ := BuildVec(spec)
+	for i := 0; i < 10; i++ {
+		g()
+	}
+	if !reflect.DeepEqual(spec.Points, []Vec(tri)) {
+		t.Errorf("points changed to %v", spec.Points)
+	}
+
+	// Valid specs whose shapes have no room for any points.
+	flat := VecSpec{Type: "poissonPolygon", Polygon: Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 20, Y: 0}}, Spacing: 1}
+	empty := []VecSpec{
+		flat,
+		{Type: "sumVec", Gens: []VecSpec{*gen, flat}},
+		{Type: "scaleVec", Gen: &flat, Factor: &NumSpec{Type: "constNum", Value: 2}},
+		{Type: "offsetVec", Gen: gen, Offset: &flat},
+	}
+	for i, spec := range empty {
+		if g, err := BuildVec(spec); err == nil {
+			t.Errorf("empty case %d: got no error, first value %#v", i, g())
+		}
+	}
+}
+
+func TestSpecValidate(t *testing.T) {
+	cases := []struct {
+		spec interface{ Validate() error }
+		want string
+	}{
+		{NumSpec{}, "type is missing"},
+		{NumSpec{Type: "randNumber"}, `unknown NumGen type "randNumber"`},
+		{NumSpec{Type: "randNum", Min: 2, Max: 1}, "randNum: min 2 is greater than max 1"},
+		{NumSpec{Type: "randNormal", StdDev: -1}, "randNormal: stdDev must not be negative, got -1"},
+		{NumSpec{Type: "randTriangular", Min: 1, Mode: 1, Max: 1}, "randTriangular: min and max must be different"},
+		{NumSpec{Type: "randWeighted", Values: []float64{1, 2}, Weights: []float64{1}},
+			"randWeighted: got 1 weights for 2 choices"},
+		{NumSpec{Type: "addNum", Gens: []NumSpec{{Type: "constNum"}}}, "addNum: needs 2 gens, got 1"},
+		{NumSpec{Type: "scaleNum", Factor: 2}, "scaleNum: gen is missing"},
+		{NumSpec{Type: "sumNum", Gens: []NumSpec{{Type: "constNum"}, {Type: "clampNum", Gen: &NumSpec{Type: "foo"}}}},
+			`sumNum: gens[1]: clampNum: gen: unknown NumGen type "foo"`},
+		{NumSpec{Type: "quantizeNum", Gen: &NumSpec{Type: "constNum"}}, "quantizeNum: step must be positive, got 0"},
+		{NumSpec{Type: "curveNum", Gen: &NumSpec{Type: "constNum"}, Points: []Vec{{X: 1}, {X: 1}}},
+			"curveNum: points must be sorted by increasing X, but points[1] is not after points[0]"},
+		{VecSpec{}, "type is missing"},
+		{VecSpec{Type: "randVecCircle", MinRadius: 3, MaxRadius: 2}, "randVecCircle: minRadius 3 is greater than maxRadius 2"},
+		{VecSpec{Type: "randVecPolygon", Polygon: Polygon{{}, {}}}, "randVecPolygon: polygon needs at least 3 vertices, got 2"},
+		{VecSpec{Type: "rotateVec", Gen: &VecSpec{Type: "staticVec"}}, "rotateVec: rad is missing"},
+		{VecSpec{Type: "scaleVec", Gen: &VecSpec{Type: "staticVec"}, Factor: &NumSpec{Type: "randExp", Mean: -1}},
+			"scaleVec: factor: randExp: mean must not be negative, got -1"},
+		{VecSpec{Type: "mixVec", Gens: []VecSpec{{Type: "staticVec"}}, Weights: []float64{-1}},
+			"mixVec: weights[0] must not be negative, got -1"},
+		{VecSpec{Type: "transformVec", Gen: &VecSpec{Type: "staticVec"}}, "transformVec: transform is missing"},
+		{VecSpec{Type: "gridVecs", Cols: 2}, "gridVecs: rows must be positive, got 0"},
+		{VecSpec{Type: "poissonCircle", Spacing: 1}, "poissonCircle: circle radius must be positive, got 0"},
+		{VecSpec{Type: "offsetVec", Gen: &VecSpec{Type: "staticVec"}, Offset: &VecSpec{Type: "cycleVec"}},
+			"offsetVec: offset: cycleVec: points must not be empty"},
+	}
+
+	for i, c := range cases {
+		err := c.spec.Validate()
+		if err == nil || err.Error() != c.want {
+			t.Errorf("case %d: got error %v, want %q", i, err, c.want)
+		}
+	}
+}
+
+func TestSpecJSON(t *testing.T) {
+	cases := []string{
+		`{"type":"clampNum","min":5,"max":15,"gen":{"type":"randNormal","mean":10,"stdDev":2}}`,
+		`{"type":"offsetVec","gen":{"type":"randVecCircle","maxRadius":10},"offset":{"type":"staticVec","vec":{"X":100,"Y":50}}}`,
+		`{"type":"rotateVec","rad":{"type":"cycleNum","values":[0,1.5]},"gen":{"type":"poissonRect","rect":{"X":0,"Y":0,"W":10,"H":10},"spacing":2,"shuffle":true}}`,
+		`{"type":"transformVec","transform":{"A":1,"B":0,"C":0,"D":2,"E":3,"F":0},"gen":{"type":"mixVec","weights":[1,2],"gens":[{"type":"staticVec"},{"type":"randVecCircleEdge","radius":1}]}}`,
+	}
+
+	for i, c := range cases {
+		var spec interface{ Validate() error }
+		if i == 0 {
+			spec = &NumSpec{}
+		} else {
+			spec = &VecSpec{}
+		}
+		if err := json.Unmarshal([]byte(c), spec); err != nil {
+			t.Errorf("case %d: %v", i, err)
+			continue
+		}
+		if err := spec.Validate(); err != nil {
+			t.Errorf("case %d: %v", i, err)
+		}
+		got, err := json.Marshal(spec)
+		if err != nil || string(got) != c {
+			t.Errorf("case %d: got %s %v, want %s", i, got, err, c)
+		}
+	}
+}
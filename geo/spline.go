@@ -0,0 +1,321 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Curve is a parametric curve that goes from At(0) to At(1).
+type Curve interface {
+	// At returns the point on the curve at t.
+	At(t float64) Vec
+	// Tangent returns the derivative of the curve at t. Its length is the speed at which
+	// At moves as t changes, so it is not normalized.
+	Tangent(t float64) Vec
+}
+
+// QuadraticBezier is a Bezier curve from P0 to P2 with control point P1. It is the same
+// curve as ggweb.Path.QuadraticCurveTo draws.
+type QuadraticBezier struct {
+	P0, P1, P2 Vec
+}
+
+var _ Curve = QuadraticBezier{}
+
+// At returns the point on the curve at t.
+func (b QuadraticBezier) At(t float64) Vec {
+	u := 1 - t
+	return b.P0.Times(u * u).Plus(b.P1.Times(2 * u * t)).Plus(b.P2.Times(t * t))
+}
+
+// Tangent returns the derivative of the curve at t.
+func (b QuadraticBezier) Tangent(t float64) Vec {
+	return b.P1.Minus(b.P0).Times(2 * (1 - t)).Plus(b.P2.Minus(b.P1).Times(2 * t))
+}
+
+// Bounds returns a Rect containing the curve's points, which also contains the curve.
+func (b QuadraticBezier) Bounds() Rect {
+	return Polygon{b.P0, b.P1, b.P2}.Bounds()
+}
+
+// Cubic returns the same curve as a CubicBezier.
+func (b QuadraticBezier) Cubic() CubicBezier {
+	return CubicBezier{
+		P0: b.P0,
+		P1: b.P0.Plus(b.P1.Minus(b.P0).Times(2.0 / 3)),
+		P2: b.P2.Plus(b.P1.Minus(b.P2).Times(2.0 / 3)),
+		P3: b.P2,
+	}
+}
+
+// Flatten returns points along the curve such that the lines between them are no
+// further than tolerance from the curve. Straighter parts of the curve get fewer points.
+func (b QuadraticBezier) Flatten(tolerance float64) []Vec {
+	return flatten(b, tolerance)
+}
+
+// CubicBezier is a Bezier curve from P0 to P3 with control points P1 and P2. It is the
+// same curve as ggweb.Path.BezierCurveTo draws.
+type CubicBezier struct {
+	P0, P1, P2, P3 Vec
+}
+
+var _ Curve = CubicBezier{}
+
+// At returns the point on the curve at t.
+func (b CubicBezier) At(t float64) Vec {
+	u := 1 - t
+	return b.P0.Times(u * u * u).
+		Plus(b.P1.Times(3 * u * u * t)).
+		Plus(b.P2.Times(3 * u * t * t)).
+		Plus(b.P3.Times(t * t * t))
+}
+
+// Tangent returns the derivative of the curve at t.
+func (b CubicBezier) Tangent(t float64) Vec {
+	u := 1 - t
+	return b.P1.Minus(b.P0).Times(3 * u * u).
+		Plus(b.P2.Minus(b.P1).Times(6 * u * t)).
+		Plus(b.P3.Minus(b.P2).Times(3 * t * t))
+}
+
+// Bounds returns a Rect containing the curve's points, which also contains the curve.
+func (b CubicBezier) Bounds() Rect {
+	return Polygon{b.P0, b.P1, b.P2, b.P3}.Bounds()
+}
+
+// Split returns the two halves of the curve on either side of t.
+func (b CubicBezier) Split(t float64) (CubicBezier, CubicBezier) {
+	lerp := func(a, b Vec) Vec { return a.Plus(b.Minus(a).Times(t)) }
+	p01, p12, p23 := lerp(b.P0, b.P1), lerp(b.P1, b.P2), lerp(b.P2, b.P3)
+	p012, p123 := lerp(p01, p12), lerp(p12, p23)
+	mid := lerp(p012, p123)
+	return CubicBezier{b.P0, p01, p012, mid}, CubicBezier{mid, p123, p23, b.P3}
+}
+
+// Flatten returns points along the curve such that the lines between them are no
+// further than tolerance from the curve. Straighter parts of the curve get fewer points.
+func (b CubicBezier) Flatten(tolerance float64) []Vec {
+	return flatten(b, tolerance)
+}
+
+// Spline is a sequence of CubicBeziers where each one starts where the previous one ends.
+// t is spread evenly across them, so At(0) is the start of the first and At(1) is the end
+// of the last. Use CatmullRom or BSpline to make a smooth one from a list of points.
+type Spline []CubicBezier
+
+var _ Curve = Spline{}
+
+// CatmullRom returns a Spline that passes through each of points. If closed is true then
+// it also connects the last point back to the first. It returns nil if there are fewer
+// than 2 points.
+func CatmullRom(points []Vec, closed bool) Spline {
+	n := len(points)
+	if n < 2 {
+		return nil
+	}
+	at := func(i int) Vec {
+		if closed {
+			return points[(i+n)%n]
+		}
+		return points[clampInt(i, 0, n-1)]
+	}
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	s := make(Spline, segments)
+	for i := range s {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		s[i] = CubicBezier{
+			P0: p1,
+			P1: p1.Plus(p2.Minus(p0).DividedBy(6)),
+			P2: p2.Minus(p3.Minus(p1).DividedBy(6)),
+			P3: p2,
+		}
+	}
+	return s
+}
+
+// BSpline returns a uniform cubic B-spline Spline with points as its control points. The
+// curve is smoother than CatmullRom but only passes near the points, not through them.
+// If closed is false then it starts and ends exactly at the first and last points. It
+// returns nil if there are fewer than 2 points.
+func BSpline(points []Vec, closed bool) Spline {
+	n := len(points)
+	if n < 2 {
+		return nil
+	}
+	at := func(i int) Vec {
+		if closed {
+			return points[(i+n)%n]
+		}
+		// Repeating the ends makes the curve reach them.
+		return points[clampInt(i-1, 0, n-1)]
+	}
+	segments := n + 1
+	if closed {
+		segments = n
+	}
+	s := make(Spline, segments)
+	for i := range s {
+		p0, p1, p2, p3 := at(i-1), at(i), at(i+1), at(i+2)
+		s[i] = CubicBezier{
+			P0: p0.Plus(p1.Times(4)).Plus(p2).DividedBy(6),
+			P1: p1.Times(2).Plus(p2).DividedBy(3),
+			P2: p1.Plus(p2.Times(2)).DividedBy(3),
+			P3: p1.Plus(p2.Times(4)).Plus(p3).DividedBy(6),
+		}
+	}
+	return s
+}
+
+// segment returns the index of the segment that t is in and how far along it t is.
+func (s Spline) segment(t float64) (int, float64) {
+	u := t * float64(len(s))
+	i := clampInt(int(math.Floor(u)), 0, len(s)-1)
+	return i, u - float64(i)
+}
+
+// At returns the point on the spline at t. An empty Spline always returns the zero vector.
+func (s Spline) At(t float64) Vec {
+	if len(s) == 0 {
+		return Vec{}
+	}
+	i, u := s.segment(t)
+	return s[i].At(u)
+}
+
+// Tangent returns the derivative of the spline at t.
+func (s Spline) Tangent(t float64) Vec {
+	if len(s) == 0 {
+		return Vec{}
+	}
+	i, u := s.segment(t)
+	return s[i].Tangent(u).Times(float64(len(s)))
+}
+
+// Bounds returns a Rect containing the control points of all segments, which also
+// contains the spline.
+func (s Spline) Bounds() Rect {
+	if len(s) == 0 {
+		return Rect{}
+	}
+	bounds := s[0].Bounds()
+	for _, b := range s[1:] {
+		bounds.Union(b.Bounds())
+	}
+	return bounds
+}
+
+// Flatten returns points along the spline such that the lines between them are no
+// further than tolerance from the spline. Straighter parts of the spline get fewer points.
+func (s Spline) Flatten(tolerance float64) []Vec {
+	if len(s) == 0 {
+		return nil
+	}
+	points := []Vec{}
+	for i, b := range s {
+		f := b.Flatten(tolerance)
+		if i > 0 {
+			f = f[1:]
+		}
+		points = append(points, f...)
+	}
+	return points
+}
+
+const (
+	minFlattenDepth = 2
+	maxFlattenDepth = 10
+)
+
+// flatten recursively splits c in half until the middle of each piece is within
+// tolerance of the line between its ends.
+func flatten(c Curve, tolerance float64) []Vec {
+	points := []Vec{c.At(0)}
+	var split func(t0, t1 float64, p0, p1 Vec, depth int)
+	split = func(t0, t1 float64, p0, p1 Vec, depth int) {
+		tm := (t0 + t1) / 2
+		pm := c.At(tm)
+		if depth >= maxFlattenDepth || depth >= minFlattenDepth && (Segment{A: p0, B: p1}).Dist(pm) <= tolerance {
+			points = append(points, p1)
+			return
+		}
+		split(t0, tm, p0, pm, depth+1)
+		split(tm, t1, pm, p1, depth+1)
+	}
+	split(0, 1, points[0], c.At(1), 0)
+	return points
+}
+
+// ArcLength maps distances along a Curve to the curve's parameter. Curves don't move at a
+// constant speed as t changes, so moving something along one by stepping t would speed
+// up and slow down. Stepping the distance instead keeps the speed constant:
+//
+//	dist += speed * dt.Seconds()
+//	enemy.Pos = path.PointAt(dist)
+type ArcLength struct {
+	curve Curve
+	// lens[i] is the length of the curve from 0 to i/(len(lens)-1).
+	lens []float64
+}
+
+// NewArcLength measures c by dividing it into the given number of samples. More samples
+// are more accurate. If samples is less than 1 then 100 is used.
+func NewArcLength(c Curve, samples int) *ArcLength {
+	if samples < 1 {
+		samples = 100
+	}
+	a := ArcLength{curve: c, lens: make([]float64, samples+1)}
+	prev := c.At(0)
+	for i := 1; i <= samples; i++ {
+		p := c.At(float64(i) / float64(samples))
+		a.lens[i] = a.lens[i-1] + p.Dist(prev)
+		prev = p
+	}
+	return &a
+}
+
+// Curve returns the measured Curve.
+func (a *ArcLength) Curve() Curve {
+	return a.curve
+}
+
+// Len returns the total length of the curve.
+func (a *ArcLength) Len() float64 {
+	return a.lens[len(a.lens)-1]
+}
+
+// Param returns the t for the point dist along the curve. dist is clamped to [0, Len()].
+func (a *ArcLength) Param(dist float64) float64 {
+	samples := float64(len(a.lens) - 1)
+	if dist <= 0 {
+		return 0
+	}
+	if dist >= a.Len() {
+		return 1
+	}
+	i := sort.SearchFloat64s(a.lens, dist)
+	l0, l1 := a.lens[i-1], a.lens[i]
+	frac := 0.0
+	if l1 > l0 {
+		frac = (dist - l0) / (l1 - l0)
+	}
+	return (float64(i-1) + frac) / samples
+}
+
+// PointAt returns the point dist along the curve.
+func (a *ArcLength) PointAt(dist float64) Vec {
+	return a.curve.At(a.Param(dist))
+}
+
+// TangentAt returns the unit length tangent at the point dist along the curve, or the
+// zero vector if the curve isn't moving there.
+func (a *ArcLength) TangentAt(dist float64) Vec {
+	t := a.curve.Tangent(a.Param(dist))
+	if t.Len2() == 0 {
+		return Vec{}
+	}
+	return t.Normalized()
+}
@@ -0,0 +1,163 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBezier(t *testing.T) {
+	q := QuadraticBezier{P0: Vec{X: 0, Y: 0}, P1: Vec{X: 1, Y: 2}, P2: Vec{X: 2, Y: 0}}
+	c := CubicBezier{P0: Vec{X: 0, Y: 0}, P1: Vec{X: 0, Y: 3}, P2: Vec{X: 3, Y: 3}, P3: Vec{X: 3, Y: 0}}
+	cases := []struct {
+		curve Curve
+		t     float64
+		want  Vec
+	}{
+		{q, 0, Vec{X: 0, Y: 0}},
+		{q, 0.5, Vec{X: 1, Y: 1}},
+		{q, 1, Vec{X: 2, Y: 0}},
+		{q.Cubic(), 0.25, q.At(0.25)},
+		{c, 0, Vec{X: 0, Y: 0}},
+		{c, 0.5, Vec{X: 1.5, Y: 2.25}},
+		{c, 1, Vec{X: 3, Y: 0}},
+	}
+
+	for i, c := range cases {
+		if got := c.curve.At(c.t); !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+
+	a, b := c.Split(0.3)
+	if !a.P3.Equals(c.At(0.3), e) || !a.At(0.5).Equals(c.At(0.15), e) || !b.At(0.5).Equals(c.At(0.65), e) {
+		t.Errorf("bad split %#v %#v", a, b)
+	}
+}
+
+func TestCurveTangent(t *testing.T) {
+	points := []Vec{{X: 0, Y: 0}, {X: 10, Y: 5}, {X: 20, Y: -5}, {X: 25, Y: 10}}
+	curves := []Curve{
+		QuadraticBezier{points[0], points[1], points[2]},
+		CubicBezier{points[0], points[1], points[2], points[3]},
+		CatmullRom(points, false),
+		BSpline(points, true),
+	}
+	const h = 1e-6
+	for i, c := range curves {
+		for _, tt := range []float64{0.1, 0.4, 0.7, 0.9} {
+			want := c.At(tt + h).Minus(c.At(tt - h)).DividedBy(2 * h)
+			if got := c.Tangent(tt); !got.Equals(want, 1e-4) {
+				t.Errorf("case %d t=%v: got %#v, want %#v", i, tt, got, want)
+			}
+		}
+	}
+}
+
+func TestSplines(t *testing.T) {
+	points := []Vec{{X: 0, Y: 0}, {X: 10, Y: 5}, {X: 20, Y: -5}, {X: 25, Y: 10}}
+
+	cr := CatmullRom(points, false)
+	if len(cr) != 3 {
+		t.Errorf("CatmullRom: got %d segments, want 3", len(cr))
+	}
+	for i, p := range points {
+		if got := cr.At(float64(i) / 3); !got.Equals(p, e) {
+			t.Errorf("CatmullRom point %d: got %#v, want %#v", i, got, p)
+		}
+	}
+
+	closed := CatmullRom(points, true)
+	if len(closed) != 4 || !closed.At(1).Equals(points[0], e) {
+		t.Errorf("closed CatmullRom: got %d segments ending at %#v", len(closed), closed.At(1))
+	}
+
+	bs := BSpline(points, false)
+	if !bs.At(0).Equals(points[0], e) || !bs.At(1).Equals(points[3], e) {
+		t.Errorf("BSpline: got ends %#v %#v", bs.At(0), bs.At(1))
+	}
+	for i := 1; i < len(bs); i++ {
+		if !bs[i-1].P3.Equals(bs[i].P0, e) || !bs[i-1].Tangent(1).Equals(bs[i].Tangent(0), e) {
+			t.Errorf("BSpline: segments %d and %d don't join smoothly", i-1, i)
+		}
+	}
+	if !BSpline(points, true).At(0).Equals(BSpline(points, true).At(1), e) {
+		t.Errorf("closed BSpline isn't closed")
+	}
+
+	if CatmullRom(points[:1], false) != nil || BSpline(nil, false) != nil {
+		t.Errorf("expected nil for fewer than 2 points")
+	}
+	if got := (Spline{}).At(0.5); got != (Vec{}) {
+		t.Errorf("empty Spline: got %#v", got)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	line := CubicBezier{P0: Vec{X: 0, Y: 0}, P1: Vec{X: 1, Y: 1}, P2: Vec{X: 2, Y: 2}, P3: Vec{X: 3, Y: 3}}
+	if got := line.Flatten(0.1); len(got) != 5 {
+		t.Errorf("straight line: got %d points, want 5", len(got))
+	}
+
+	curves := []interface {
+		Curve
+		Flatten(float64) []Vec
+	}{
+		QuadraticBezier{Vec{X: 0, Y: 0}, Vec{X: 50, Y: 100}, Vec{X: 100, Y: 0}},
+		CubicBezier{Vec{X: 0, Y: 0}, Vec{X: 0, Y: 100}, Vec{X: 100, Y: -100}, Vec{X: 100, Y: 0}},
+		CatmullRom([]Vec{{X: 0, Y: 0}, {X: 50, Y: 50}, {X: 100, Y: 0}, {X: 50, Y: -50}}, true),
+	}
+	for i, c := range curves {
+		for _, tol := range []float64{1, 0.1} {
+			points := c.Flatten(tol)
+			if !points[0].Equals(c.At(0), e) || !points[len(points)-1].Equals(c.At(1), e) {
+				t.Errorf("case %d: doesn't start and end with the curve", i)
+			}
+			poly := Polygon(points)
+			for j := 0; j <= 200; j++ {
+				p := c.At(float64(j) / 200)
+				best := math.Inf(1)
+				for k := 1; k < len(poly); k++ {
+					best = math.Min(best, Segment{A: poly[k-1], B: poly[k]}.Dist(p))
+				}
+				if best > tol*1.5 {
+					t.Errorf("case %d tol %v: point %#v is %v from the lines", i, tol, p, best)
+					break
+				}
+			}
+		}
+		if len(c.Flatten(0.1)) <= len(c.Flatten(1)) {
+			t.Errorf("case %d: lower tolerance didn't add points", i)
+		}
+	}
+}
+
+func TestArcLength(t *testing.T) {
+	line := QuadraticBezier{P0: Vec{X: 0, Y: 0}, P1: Vec{X: 9, Y: 0}, P2: Vec{X: 10, Y: 0}}
+	a := NewArcLength(line, 1000)
+	if got := a.Len(); math.Abs(got-10) > 1e-9 {
+		t.Errorf("Len: got %v, want 10", got)
+	}
+	for _, d := range []float64{-1, 0, 2.5, 5, 7.5, 10, 11} {
+		want := Vec{X: math.Max(0, math.Min(10, d))}
+		if got := a.PointAt(d); !got.Equals(want, 1e-3) {
+			t.Errorf("PointAt(%v): got %#v, want %#v", d, got, want)
+		}
+	}
+	if got := a.TangentAt(5); !got.Equals(Vec{X: 1}, e) {
+		t.Errorf("TangentAt: got %#v", got)
+	}
+
+	circle := CatmullRom([]Vec{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: -1}}, true)
+	a = NewArcLength(circle, 0)
+	if got, want := a.Len(), NewArcLength(circle, 10000).Len(); math.Abs(got-want) > 1e-2 {
+		t.Errorf("circle Len: got %v, want %v", got, want)
+	}
+	prev := a.PointAt(0)
+	for d := 0.1; d < a.Len(); d += 0.1 {
+		p := a.PointAt(d)
+		if step := p.Dist(prev); math.Abs(step-0.1) > 2e-3 {
+			t.Errorf("step at %v: got %v, want 0.1", d, step)
+		}
+		prev = p
+	}
+}
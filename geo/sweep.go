@@ -0,0 +1,109 @@
+package geo
+
+import "math"
+
+// SweepHit describes the first contact made by a Rect moving against a list of others.
+type SweepHit struct {
+	// Index is the index of the Rect that was hit, or -1 if none were.
+	Index int
+	// Time is the fraction of the motion completed before contact, in the range [0, 1].
+	// It is 1 if nothing was hit.
+	Time float64
+	// Normal is the unit surface normal of the Rect that was hit, facing the moving Rect.
+	// It is always axis aligned.
+	Normal Vec
+}
+
+// Sweep moves r by vel and returns the fraction of vel, in the range [0, 1], travelled
+// before it first touches other, along with the surface normal of other at the point of
+// contact. Rects that are only touching are not considered colliding, and if r and other
+// already overlap then other is ignored so that r is free to move out of it.
+func (r Rect) Sweep(vel Vec, other Rect) (t float64, normal Vec, ok bool) {
+	// Sweeping r against other is the same as sweeping r's top left corner against other
+	// grown by r's size.
+	tEnter, tExit := math.Inf(-1), math.Inf(1)
+	axes := [2]struct {
+		o, d, min, max float64
+		n              Vec
+	}{
+		{r.X, vel.X, other.X - r.W, other.Right(), Vec{X: 1}},
+		{r.Y, vel.Y, other.Y - r.H, other.Bottom(), Vec{Y: 1}},
+	}
+	for _, a := range axes {
+		if a.d == 0 {
+			if a.o <= a.min || a.o >= a.max {
+				return 0, Vec{}, false
+			}
+			continue
+		}
+		t1, t2 := (a.min-a.o)/a.d, (a.max-a.o)/a.d
+		n := a.n.Times(-1)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+			n = a.n
+		}
+		if t1 > tEnter {
+			tEnter, normal = t1, n
+		}
+		tExit = math.Min(tExit, t2)
+	}
+	if tEnter >= tExit || tEnter < 0 || tEnter > 1 {
+		return 0, Vec{}, false
+	}
+	return tEnter, normal, true
+}
+
+// SweepList moves r by vel and returns the first of others that it touches. If none are
+// hit then the returned Index is -1 and Time is 1.
+func (r Rect) SweepList(vel Vec, others []Rect) SweepHit {
+	hit := SweepHit{Index: -1, Time: 1}
+	for i, other := range others {
+		t, normal, ok := r.Sweep(vel, other)
+		if ok && (hit.Index < 0 || t < hit.Time) {
+			hit = SweepHit{Index: i, Time: t, Normal: normal}
+		}
+	}
+	return hit
+}
+
+// maxSlides is the number of times MoveAndSlide will redirect the motion before giving up.
+const maxSlides = 4
+
+// MoveAndSlide moves r by vel, in place, without passing through any of others. When it
+// hits a Rect it stops against it and continues with the remaining motion along the
+// surface, so a character falling diagonally onto the ground keeps moving horizontally.
+// It returns vel with the parts going into any surfaces removed, which is useful as the
+// new velocity for the character, and each contact made, in order. The Time of each
+// contact is relative to the motion remaining at that point.
+func (r *Rect) MoveAndSlide(vel Vec, others []Rect) (Vec, []SweepHit) {
+	var hits []SweepHit
+	remaining := vel
+	for i := 0; i < maxSlides && remaining != (Vec{}); i++ {
+		hit := r.SweepList(remaining, others)
+		r.Move(remaining.X*hit.Time, remaining.Y*hit.Time)
+		if hit.Index < 0 {
+			break
+		}
+		hits = append(hits, hit)
+
+		// Snap to the surface to avoid drifting into it from rounding error.
+		other := others[hit.Index]
+		switch {
+		case hit.Normal.X < 0:
+			r.SetRight(other.Left())
+		case hit.Normal.X > 0:
+			r.SetLeft(other.Right())
+		case hit.Normal.Y < 0:
+			r.SetBottom(other.Top())
+		case hit.Normal.Y > 0:
+			r.SetTop(other.Bottom())
+		}
+
+		remaining.Mul(1 - hit.Time)
+		remaining.Sub(hit.Normal.Times(remaining.Dot(hit.Normal)))
+		if d := vel.Dot(hit.Normal); d < 0 {
+			vel.Sub(hit.Normal.Times(d))
+		}
+	}
+	return vel, hits
+}
@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRectSweep(t *testing.T) {
+	r := Rect{X: 0, Y: 0, W: 2, H: 2}
+	cases := []struct {
+		vel    Vec
+		other  Rect
+		ok     bool
+		t      float64
+		normal Vec
+	}{
+		{Vec{X: 10}, Rect{X: 6, Y: 0, W: 1, H: 2}, true, 0.4, Vec{X: -1}},
+		// Thin wall that a single CollideRect at the end position would miss.
+		{Vec{X: 100}, Rect{X: 50, Y: -10, W: 0.1, H: 20}, true, 0.48, Vec{X: -1}},
+		{Vec{Y: -10}, Rect{X: 1, Y: -5, W: 5, H: 1}, true, 0.4, Vec{Y: 1}},
+		{Vec{X: 10}, Rect{X: 6, Y: 2, W: 1, H: 2}, false, 0, Vec{}},
+		{Vec{X: 2}, Rect{X: 6, Y: 0, W: 1, H: 2}, false, 0, Vec{}},
+		{Vec{X: -10}, Rect{X: 6, Y: 0, W: 1, H: 2}, false, 0, Vec{}},
+		{Vec{X: 10, Y: 10}, Rect{X: 4, Y: 6, W: 4, H: 4}, true, 0.4, Vec{Y: -1}},
+		// Touching and moving into it.
+		{Vec{X: 1}, Rect{X: 2, Y: 0, W: 1, H: 2}, true, 0, Vec{X: -1}},
+		// Touching and moving along it.
+		{Vec{X: 5}, Rect{X: -5, Y: 2, W: 20, H: 1}, false, 0, Vec{}},
+		// Already overlapping.
+		{Vec{X: 5}, Rect{X: 1, Y: 1, W: 2, H: 2}, false, 0, Vec{}},
+	}
+
+	for i, c := range cases {
+		got, normal, ok := r.Sweep(c.vel, c.other)
+		if ok != c.ok || math.Abs(got-c.t) > e || !normal.Equals(c.normal, e) {
+			t.Errorf("case %d: got %v %v %#v, want %v %v %#v", i, ok, got, normal, c.ok, c.t, c.normal)
+		}
+	}
+}
+
+func TestRectSweepList(t *testing.T) {
+	r := Rect{X: 0, Y: 0, W: 2, H: 2}
+	others := []Rect{
+		{X: 8, Y: 0, W: 1, H: 2},
+		{X: 0, Y: 5, W: 1, H: 2},
+		{X: 4, Y: 1, W: 1, H: 2},
+	}
+	hit := r.SweepList(Vec{X: 10}, others)
+	if hit.Index != 2 || math.Abs(hit.Time-0.2) > e || hit.Normal != (Vec{X: -1}) {
+		t.Errorf("got %#v", hit)
+	}
+	hit = r.SweepList(Vec{X: -10}, others)
+	if hit.Index != -1 || hit.Time != 1 {
+		t.Errorf("got %#v, want no hit", hit)
+	}
+}
+
+func TestRectMoveAndSlide(t *testing.T) {
+	ground := []Rect{
+		{X: -100, Y: 10, W: 200, H: 10},
+		{X: 20, Y: -100, W: 10, H: 200},
+	}
+
+	// Falling diagonally onto the ground slides along it.
+	r := Rect{X: 0, Y: 0, W: 2, H: 2}
+	vel, hits := r.MoveAndSlide(Vec{X: 5, Y: 16}, ground)
+	if want := (Rect{X: 5, Y: 8, W: 2, H: 2}); !rectEquals(r, want) {
+		t.Errorf("landing: got %#v, want %#v", r, want)
+	}
+	if vel != (Vec{X: 5}) || len(hits) != 1 || hits[0].Index != 0 {
+		t.Errorf("landing: got vel %#v hits %#v", vel, hits)
+	}
+
+	// Sliding along the ground into a wall stops at both.
+	vel, hits = r.MoveAndSlide(Vec{X: 50, Y: 1}, ground)
+	if want := (Rect{X: 18, Y: 8, W: 2, H: 2}); !rectEquals(r, want) {
+		t.Errorf("wall: got %#v, want %#v", r, want)
+	}
+	if vel != (Vec{}) || len(hits) != 2 {
+		t.Errorf("wall: got vel %#v hits %#v", vel, hits)
+	}
+
+	// Nothing in the way.
+	vel, hits = r.MoveAndSlide(Vec{X: -5, Y: -5}, ground)
+	if want := (Rect{X: 13, Y: 3, W: 2, H: 2}); !rectEquals(r, want) || vel != (Vec{X: -5, Y: -5}) || len(hits) != 0 {
+		t.Errorf("free: got %#v vel %#v hits %#v", r, vel, hits)
+	}
+}
+
+func rectEquals(r1, r2 Rect) bool {
+	return math.Abs(r1.X-r2.X) < e && math.Abs(r1.Y-r2.Y) < e && math.Abs(r1.W-r2.W) < e &&
+		math.Abs(r1.H-r2.H) < e
+}
@@ -0,0 +1,145 @@
+package geo
+
+import "math"
+
+// Transform is a 2D affine transformation matrix. The fields are laid out the same way as
+// the canvas transform functions expect:
+//
+//	[ A C E ]
+//	[ B D F ]
+//	[ 0 0 1 ]
+//
+// The zero value is not the identity, use IdentityTransform for that. Rotations follow
+// the same convention as Vec.Rotate, counterclockwise in screen coordinates.
+type Transform struct {
+	A, B, C, D, E, F float64
+}
+
+// IdentityTransform returns the Transform that leaves everything unchanged.
+func IdentityTransform() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// TranslateTransform returns a Transform that moves by the given offset.
+func TranslateTransform(dx, dy float64) Transform {
+	return Transform{A: 1, D: 1, E: dx, F: dy}
+}
+
+// RotateTransform returns a Transform that rotates (counterclockwise in screen coordinates)
+// about the origin by the given radians.
+func RotateTransform(rad float64) Transform {
+	sin, cos := math.Sincos(rad)
+	return Transform{A: cos, B: -sin, C: sin, D: cos}
+}
+
+// ScaleTransform returns a Transform that scales by the given factors.
+func ScaleTransform(sx, sy float64) Transform {
+	return Transform{A: sx, D: sy}
+}
+
+// Mul modifies t to be t times other. The result applies other first and then t, which is
+// the same way the canvas combines transforms.
+func (t *Transform) Mul(other Transform) {
+	*t = t.Times(other)
+}
+
+// Times returns t times other. The result applies other first and then t, which is the
+// same way the canvas combines transforms.
+func (t Transform) Times(other Transform) Transform {
+	return Transform{
+		A: t.A*other.A + t.C*other.B,
+		B: t.B*other.A + t.D*other.B,
+		C: t.A*other.C + t.C*other.D,
+		D: t.B*other.C + t.D*other.D,
+		E: t.A*other.E + t.C*other.F + t.E,
+		F: t.B*other.E + t.D*other.F + t.F,
+	}
+}
+
+// Translate modifies t to move by the given offset before applying itself.
+func (t *Transform) Translate(dx, dy float64) {
+	t.Mul(TranslateTransform(dx, dy))
+}
+
+// Translated returns a new Transform that moves by the given offset before applying t.
+func (t Transform) Translated(dx, dy float64) Transform {
+	return t.Times(TranslateTransform(dx, dy))
+}
+
+// Rotate modifies t to rotate by the given radians before applying itself.
+func (t *Transform) Rotate(rad float64) {
+	t.Mul(RotateTransform(rad))
+}
+
+// Rotated returns a new Transform that rotates by the given radians before applying t.
+func (t Transform) Rotated(rad float64) Transform {
+	return t.Times(RotateTransform(rad))
+}
+
+// Scale modifies t to scale by the given factors before applying itself.
+func (t *Transform) Scale(sx, sy float64) {
+	t.Mul(ScaleTransform(sx, sy))
+}
+
+// Scaled returns a new Transform that scales by the given factors before applying t.
+func (t Transform) Scaled(sx, sy float64) Transform {
+	return t.Times(ScaleTransform(sx, sy))
+}
+
+// Det returns the determinant of the matrix. A determinant of 0 means the Transform cannot
+// be inverted.
+func (t Transform) Det() float64 {
+	return t.A*t.D - t.B*t.C
+}
+
+// Inverse returns the Transform that undoes t. If t cannot be inverted then ok is false.
+func (t Transform) Inverse() (inv Transform, ok bool) {
+	det := t.Det()
+	if det == 0 {
+		return Transform{}, false
+	}
+	return Transform{
+		A: t.D / det,
+		B: -t.B / det,
+		C: -t.C / det,
+		D: t.A / det,
+		E: (t.C*t.F - t.D*t.E) / det,
+		F: (t.B*t.E - t.A*t.F) / det,
+	}, true
+}
+
+// Apply returns the point v transformed by t.
+func (t Transform) Apply(v Vec) Vec {
+	return Vec{X: t.A*v.X + t.C*v.Y + t.E, Y: t.B*v.X + t.D*v.Y + t.F}
+}
+
+// ApplyDir returns the direction v transformed by t, that is without translation.
+func (t Transform) ApplyDir(v Vec) Vec {
+	return Vec{X: t.A*v.X + t.C*v.Y, Y: t.B*v.X + t.D*v.Y}
+}
+
+// ApplyRect returns the smallest Rect that contains r after it is transformed by t.
+func (t Transform) ApplyRect(r Rect) Rect {
+	corners := [4]Vec{
+		t.Apply(Vec{X: r.Left(), Y: r.Top()}),
+		t.Apply(Vec{X: r.Right(), Y: r.Top()}),
+		t.Apply(Vec{X: r.Left(), Y: r.Bottom()}),
+		t.Apply(Vec{X: r.Right(), Y: r.Bottom()}),
+	}
+	return Polygon(corners[:]).Bounds()
+}
+
+// ApplyPolygon returns a new Polygon with each vertex transformed by t.
+func (t Transform) ApplyPolygon(p Polygon) Polygon {
+	out := make(Polygon, len(p))
+	for i, v := range p {
+		out[i] = t.Apply(v)
+	}
+	return out
+}
+
+// Equals returns true if the corresponding elements of the matrices are within the error e.
+func (t Transform) Equals(other Transform, e float64) bool {
+	return math.Abs(t.A-other.A) < e && math.Abs(t.B-other.B) < e && math.Abs(t.C-other.C) < e &&
+		math.Abs(t.D-other.D) < e && math.Abs(t.E-other.E) < e && math.Abs(t.F-other.F) < e
+}
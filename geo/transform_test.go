@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTransformApply(t *testing.T) {
+	cases := []struct {
+		t    Transform
+		v    Vec
+		want Vec
+	}{
+		{IdentityTransform(), Vec{X: 3, Y: 4}, Vec{X: 3, Y: 4}},
+		{TranslateTransform(1, 2), Vec{X: 3, Y: 4}, Vec{X: 4, Y: 6}},
+		{ScaleTransform(2, 3), Vec{X: 3, Y: 4}, Vec{X: 6, Y: 12}},
+		{RotateTransform(math.Pi / 2), Vec{X: 1, Y: 0}, Vec{X: 1, Y: 0}.Rotated(math.Pi / 2)},
+		{RotateTransform(0.3), Vec{X: 3, Y: 4}, Vec{X: 3, Y: 4}.Rotated(0.3)},
+		// Translate is applied last since it is on the left.
+		{TranslateTransform(10, 0).Times(ScaleTransform(2, 2)), Vec{X: 1, Y: 1}, Vec{X: 12, Y: 2}},
+		{TranslateTransform(10, 0).Scaled(2, 2), Vec{X: 1, Y: 1}, Vec{X: 12, Y: 2}},
+		{ScaleTransform(2, 2).Translated(10, 0), Vec{X: 1, Y: 1}, Vec{X: 22, Y: 2}},
+		{IdentityTransform().Rotated(math.Pi).Translated(1, 0), Vec{}, Vec{X: -1}},
+	}
+
+	for i, c := range cases {
+		got := c.t.Apply(c.v)
+		if !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestTransformInPlace(t *testing.T) {
+	got := IdentityTransform()
+	got.Translate(5, 6)
+	got.Rotate(1)
+	got.Scale(2, 3)
+	want := IdentityTransform().Translated(5, 6).Rotated(1).Scaled(2, 3)
+	if !got.Equals(want, e) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	got.Mul(RotateTransform(-1))
+	want = want.Times(RotateTransform(-1))
+	if !got.Equals(want, e) {
+		t.Errorf("Mul: got %#v, want %#v", got, want)
+	}
+}
+
+func TestTransformInverse(t *testing.T) {
+	cases := []Transform{
+		IdentityTransform(),
+		TranslateTransform(5, -3),
+		RotateTransform(1.2).Scaled(2, 0.5).Translated(10, 20),
+		{A: 1, B: 0, C: 1, D: 1, E: 40, F: 10},
+	}
+
+	for i, tr := range cases {
+		inv, ok := tr.Inverse()
+		if !ok {
+			t.Errorf("case %d: not invertible", i)
+			continue
+		}
+		if got := tr.Times(inv); !got.Equals(IdentityTransform(), e) {
+			t.Errorf("case %d: t * inv = %#v", i, got)
+		}
+		v := Vec{X: 7, Y: -2}
+		if got := inv.Apply(tr.Apply(v)); !got.Equals(v, e) {
+			t.Errorf("case %d: round trip got %#v, want %#v", i, got, v)
+		}
+	}
+
+	if _, ok := ScaleTransform(0, 1).Inverse(); ok {
+		t.Errorf("singular transform reported as invertible")
+	}
+}
+
+func TestTransformApplyRect(t *testing.T) {
+	cases := []struct {
+		t    Transform
+		r    Rect
+		want Rect
+	}{
+		{TranslateTransform(1, 2), Rect{X: 0, Y: 0, W: 2, H: 2}, Rect{X: 1, Y: 2, W: 2, H: 2}},
+		{ScaleTransform(-2, 1), Rect{X: 1, Y: 0, W: 2, H: 2}, Rect{X: -6, Y: 0, W: 4, H: 2}},
+		{RotateTransform(math.Pi / 4), Rect{X: -1, Y: -1, W: 2, H: 2},
+			Rect{X: -math.Sqrt2, Y: -math.Sqrt2, W: 2 * math.Sqrt2, H: 2 * math.Sqrt2}},
+	}
+
+	for i, c := range cases {
+		got := c.t.ApplyRect(c.r)
+		if !rectEquals(got, c.want) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
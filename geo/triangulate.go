@@ -0,0 +1,279 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Triangulate splits the simple Polygon p into triangles using ear clipping. Any holes
+// must be inside p and not touch p or each other. The triangles are wound clockwise no
+// matter the winding of p and the holes.
+func Triangulate(p Polygon, holes ...Polygon) []Polygon {
+	return earClip(mergeHoles(p, holes))
+}
+
+// ConvexDecompose splits the simple Polygon p into convex Polygons using the
+// Hertel-Mehlhorn algorithm, which gives at most four times the fewest possible pieces.
+// The holes are the same as for Triangulate. Each piece is wound clockwise. The pieces can
+// be used with the Polygon collision functions, which only work with convex Polygons.
+func ConvexDecompose(p Polygon, holes ...Polygon) []Polygon {
+	pieces := Triangulate(p, holes...)
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(pieces); i++ {
+			for j := i + 1; j < len(pieces); j++ {
+				if m, ok := mergeConvex(pieces[i], pieces[j]); ok {
+					pieces[i] = m
+					pieces = append(pieces[:j], pieces[j+1:]...)
+					merged = true
+					j = i
+				}
+			}
+		}
+	}
+	return pieces
+}
+
+// GroupHoles sorts out a list of outlines, such as the result of PolygonDifference, into
+// groups where the first Polygon in each is an outer boundary and the rest are its holes.
+// An outline inside an odd number of others is a hole. Each group can be passed straight
+// to Triangulate or ConvexDecompose:
+//
+//	for _, g := range GroupHoles(terrain) {
+//		pieces = append(pieces, ConvexDecompose(g[0], g[1:]...)...)
+//	}
+func GroupHoles(outlines []Polygon) [][]Polygon {
+	depth := make([]int, len(outlines))
+	for i, p := range outlines {
+		if len(p) == 0 {
+			continue
+		}
+		for j, other := range outlines {
+			if i != j && other.CollidePoint(p[0].X, p[0].Y) {
+				depth[i]++
+			}
+		}
+	}
+
+	groups := [][]Polygon{}
+	group := make([]int, len(outlines))
+	for i, p := range outlines {
+		if len(p) >= 3 && depth[i]%2 == 0 {
+			group[i] = len(groups)
+			groups = append(groups, []Polygon{p})
+		}
+	}
+	for i, p := range outlines {
+		if len(p) < 3 || depth[i]%2 == 0 {
+			continue
+		}
+		// A hole belongs to the smallest outer boundary around it.
+		best, bestArea := -1, math.Inf(1)
+		for j, outer := range outlines {
+			if depth[j] == depth[i]-1 && outer.CollidePoint(p[0].X, p[0].Y) && outer.Area() < bestArea {
+				best, bestArea = j, outer.Area()
+			}
+		}
+		if best >= 0 {
+			groups[group[best]] = append(groups[group[best]], p)
+		}
+	}
+	return groups
+}
+
+// mergeHoles joins each hole to p with a pair of edges, a bridge, so that the result is a
+// single clockwise outline. Holes that can't be bridged are left out.
+func mergeHoles(p Polygon, holes []Polygon) Polygon {
+	outer := removeCollinear(p)
+	if !outer.Clockwise() {
+		outer.Reverse()
+	}
+	hs := make([]Polygon, 0, len(holes))
+	for _, h := range holes {
+		h = removeCollinear(h)
+		if len(h) < 3 {
+			continue
+		}
+		if h.Clockwise() {
+			h.Reverse()
+		}
+		hs = append(hs, h)
+	}
+	// Bridging the rightmost holes first leaves the most room for the rest.
+	rightmost := func(h Polygon) int {
+		m := 0
+		for i, v := range h {
+			if v.X > h[m].X {
+				m = i
+			}
+		}
+		return m
+	}
+	sort.Slice(hs, func(i, j int) bool {
+		return hs[i][rightmost(hs[i])].X > hs[j][rightmost(hs[j])].X
+	})
+
+	for hi, h := range hs {
+		m := rightmost(h)
+		order := make([]int, len(outer))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			return outer[order[i]].Dist2(h[m]) < outer[order[j]].Dist2(h[m])
+		})
+		for _, k := range order {
+			bridge := Segment{A: h[m], B: outer[k]}
+			if crossesAny(bridge, outer) || crossesAny(bridge, hs[hi:]...) {
+				continue
+			}
+			merged := make(Polygon, 0, len(outer)+len(h)+2)
+			merged = append(merged, outer[:k+1]...)
+			merged = append(merged, h[m:]...)
+			merged = append(merged, h[:m+1]...)
+			merged = append(merged, outer[k:]...)
+			outer = merged
+			break
+		}
+	}
+	return outer
+}
+
+// crossesAny returns true if s touches any edge of polys that doesn't share an end with s.
+func crossesAny(s Segment, polys ...Polygon) bool {
+	for _, p := range polys {
+		for i := range p {
+			edge := Segment{A: p[i], B: p[(i+1)%len(p)]}
+			if edge.A == s.A || edge.A == s.B || edge.B == s.A || edge.B == s.B {
+				continue
+			}
+			if _, ok := s.IntersectSegment(edge); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// earClip triangulates a clockwise outline. The outline may touch itself at vertices,
+// which is the case for the bridges made by mergeHoles.
+func earClip(p Polygon) []Polygon {
+	idx := make([]int, len(p))
+	for i := range idx {
+		idx[i] = i
+	}
+	tris := make([]Polygon, 0, len(p))
+	for len(idx) > 3 {
+		found := false
+		for i := range idx {
+			prev, next := (i+len(idx)-1)%len(idx), (i+1)%len(idx)
+			a, b, c := p[idx[prev]], p[idx[i]], p[idx[next]]
+			turn := cross(b.Minus(a), c.Minus(b))
+			if turn < 0 {
+				continue
+			}
+			if turn > 0 && !anyInTriangle(p, idx, a, b, c) {
+				tris = append(tris, Polygon{a, b, c})
+			} else if turn > 0 {
+				continue
+			}
+			// Either an ear was clipped or b is in a straight line and can be dropped.
+			idx = append(idx[:i], idx[i+1:]...)
+			found = true
+			break
+		}
+		if !found {
+			// Only possible if the outline isn't simple.
+			return tris
+		}
+	}
+	if len(idx) == 3 {
+		a, b, c := p[idx[0]], p[idx[1]], p[idx[2]]
+		if cross(b.Minus(a), c.Minus(b)) > 0 {
+			tris = append(tris, Polygon{a, b, c})
+		}
+	}
+	return tris
+}
+
+// anyInTriangle returns true if any of the vertices of p in idx, other than those at a, b
+// or c, are inside or on the edge of the clockwise triangle abc.
+func anyInTriangle(p Polygon, idx []int, a, b, c Vec) bool {
+	for _, i := range idx {
+		v := p[i]
+		if v == a || v == b || v == c {
+			continue
+		}
+		if cross(b.Minus(a), v.Minus(a)) >= 0 && cross(c.Minus(b), v.Minus(b)) >= 0 &&
+			cross(a.Minus(c), v.Minus(c)) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeConvex joins two clockwise Polygons that share an edge if the result is convex.
+func mergeConvex(a, b Polygon) (Polygon, bool) {
+	for i := range a {
+		u, v := a[i], a[(i+1)%len(a)]
+		for j := range b {
+			if b[j] != v || b[(j+1)%len(b)] != u {
+				continue
+			}
+			// Go around a from v to u, then around b between u and v.
+			merged := make(Polygon, 0, len(a)+len(b)-2)
+			for k := 0; k < len(a); k++ {
+				merged = append(merged, a[(i+1+k)%len(a)])
+			}
+			for k := 2; k < len(b); k++ {
+				merged = append(merged, b[(j+k)%len(b)])
+			}
+			merged = removeCollinear(merged)
+			if !convexClockwise(merged) {
+				return nil, false
+			}
+			return merged, true
+		}
+	}
+	return nil, false
+}
+
+// convexClockwise returns true if p only turns clockwise and goes around exactly once.
+func convexClockwise(p Polygon) bool {
+	if len(p) < 3 {
+		return false
+	}
+	total := 0.0
+	for i := range p {
+		a, b, c := p[i], p[(i+1)%len(p)], p[(i+2)%len(p)]
+		d1, d2 := b.Minus(a), c.Minus(b)
+		turn := cross(d1, d2)
+		if turn < 0 {
+			return false
+		}
+		total += math.Atan2(turn, d1.Dot(d2))
+	}
+	return math.Abs(total-2*math.Pi) < 1e-6
+}
+
+// removeCollinear returns a copy of p without repeated vertices or vertices that are in a
+// straight line with their neighbors.
+func removeCollinear(p Polygon) Polygon {
+	r := p.Copy()
+	for changed := true; changed && len(r) >= 3; {
+		changed = false
+		for i := 0; i < len(r) && len(r) >= 3; i++ {
+			a, b, c := r[(i+len(r)-1)%len(r)], r[i], r[(i+1)%len(r)]
+			d1, d2 := b.Minus(a), c.Minus(b)
+			if math.Abs(cross(d1, d2)) <= 1e-9*d1.Len()*d2.Len() {
+				r = append(r[:i], r[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	if len(r) < 3 {
+		return Polygon{}
+	}
+	return r
+}
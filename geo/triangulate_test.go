@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+var (
+	lShape = Polygon{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}}
+	square = RectPolygon(Rect{X: 0, Y: 0, W: 10, H: 10})
+	holes  = []Polygon{
+		RectPolygon(Rect{X: 2, Y: 2, W: 2, H: 2}),
+		{{X: 6, Y: 6}, {X: 8, Y: 7}, {X: 7, Y: 8}},
+	}
+)
+
+func TestTriangulate(t *testing.T) {
+	cases := []struct {
+		p     Polygon
+		holes []Polygon
+		tris  int
+		area  float64
+	}{
+		{unitSquare, nil, 2, 1},
+		{unitSquare.Reversed(), nil, 2, 1},
+		{lShape, nil, 4, 3},
+		{square, holes, 13, 100 - 4 - 1.5},
+	}
+
+	for i, c := range cases {
+		tris := Triangulate(c.p, c.holes...)
+		if len(tris) != c.tris {
+			t.Errorf("case %d: got %d triangles, want %d", i, len(tris), c.tris)
+		}
+		area := 0.0
+		for _, tri := range tris {
+			if len(tri) != 3 || !tri.Clockwise() {
+				t.Errorf("case %d: bad triangle %#v", i, tri)
+			}
+			area += tri.Area()
+			center := tri.Centroid()
+			if !c.p.CollidePoint(center.X, center.Y) || regionContains(c.holes, center) {
+				t.Errorf("case %d: triangle %#v is outside the polygon", i, tri)
+			}
+		}
+		if math.Abs(area-c.area) > e {
+			t.Errorf("case %d: got area %v, want %v", i, area, c.area)
+		}
+	}
+}
+
+func TestConvexDecompose(t *testing.T) {
+	cases := []struct {
+		p     Polygon
+		holes []Polygon
+		max   int
+		area  float64
+	}{
+		{unitSquare, nil, 1, 1},
+		{lShape, nil, 2, 3},
+		{square, holes, 8, 100 - 4 - 1.5},
+	}
+
+	for i, c := range cases {
+		pieces := ConvexDecompose(c.p, c.holes...)
+		if len(pieces) > c.max {
+			t.Errorf("case %d: got %d pieces, want at most %d", i, len(pieces), c.max)
+		}
+		area := 0.0
+		for _, p := range pieces {
+			if !convexClockwise(p) {
+				t.Errorf("case %d: piece isn't convex: %#v", i, p)
+			}
+			area += p.Area()
+		}
+		if math.Abs(area-c.area) > e {
+			t.Errorf("case %d: got area %v, want %v", i, area, c.area)
+		}
+	}
+}
+
+func TestGroupHoles(t *testing.T) {
+	island := RectPolygon(Rect{X: 2.5, Y: 2.5, W: 1, H: 1})
+	other := RectPolygon(Rect{X: 20, Y: 0, W: 1, H: 1})
+	groups := GroupHoles([]Polygon{holes[0], island, square, other, holes[1]})
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3", len(groups))
+	}
+	want := []struct {
+		outer Polygon
+		holes int
+	}{{island, 0}, {square, 2}, {other, 0}}
+	for i, w := range want {
+		if groups[i][0][0] != w.outer[0] || len(groups[i])-1 != w.holes {
+			t.Errorf("group %d: got %#v", i, groups[i])
+		}
+	}
+}
@@ -1,11 +1,6 @@
 package geo
 
-import (
-	"math"
-	"math/rand"
-
-	"github.com/Bredgren/wrand"
-)
+import "math"
 
 // Vec is a 2D vector. Many of the functions for Vec have two versions, one that modifies
 // the Vec and one that returns a new Vec. Their names follow a convention that is hopefully
@@ -193,8 +188,7 @@ type VecGen func() Vec
 
 // RandVec returns a unit vector in a random direction.
 func RandVec() Vec {
-	rad := rand.Float64() * 2 * math.Pi
-	return Vec{X: math.Cos(rad), Y: math.Sin(rad)}
+	return globalRng.RandVec()
 }
 
 // StaticVec returns a VecGen that always returns the constant vector v.
@@ -224,61 +218,172 @@ func OffsetVec(gen VecGen, offset VecGen) VecGen {
 // RandVecCircle returns a VecGen that will generate a random vector within the given radii.
 // Negative radii are undefined.
 func RandVecCircle(minRadius, maxRadius float64) VecGen {
-	return func() Vec {
-		return RandVec().Times(circleRadius(minRadius, maxRadius))
-	}
+	return globalRng.RandVecCircle(minRadius, maxRadius)
 }
 
 // RandVecArc returns a VecGen that will generate a random vector within the slice of a
 // circle defined by the parameters. The radians are relative to the +x axis.
 // Negative radii are undefined.
 func RandVecArc(minRadius, maxRadius, minRadians, maxRadians float64) VecGen {
-	if maxRadians < minRadians {
-		minRadians, maxRadians = maxRadians, minRadians
-	}
-	return func() Vec {
-		r := circleRadius(minRadius, maxRadius)
-		rad := rand.Float64()*(maxRadians-minRadians) + minRadians
-		return Vec{X: r}.Rotated(rad)
-	}
+	return globalRng.RandVecArc(minRadius, maxRadius, minRadians, maxRadians)
 }
 
 // RandVecRect returns a VecGen that will generate a random vector within the given Rect.
 func RandVecRect(rect Rect) VecGen {
-	return func() Vec {
-		return Vec{
-			X: rand.Float64()*rect.W + rect.X,
-			Y: rand.Float64()*rect.H + rect.Y,
-		}
-	}
+	return globalRng.RandVecRect(rect)
 }
 
 // RandVecRects returns a VecGen that will generate a random vector that is uniformly
 // distributed between all the given rects. If the slice given is empty then the zero
 // vector is returned.
 func RandVecRects(rects []Rect) VecGen {
-	if len(rects) == 0 {
-		return func() Vec { return Vec{} }
+	return globalRng.RandVecRects(rects)
+}
+
+// RandVecSegment returns a VecGen that will generate a random vector along the Segment s.
+func RandVecSegment(s Segment) VecGen {
+	return globalRng.RandVecSegment(s)
+}
+
+// RandVecCircleEdge returns a VecGen that will generate a random vector exactly radius
+// from the origin.
+func RandVecCircleEdge(radius float64) VecGen {
+	return globalRng.RandVecCircleEdge(radius)
+}
+
+// RandVecPolygon returns a VecGen that will generate a random vector that is uniformly
+// distributed within the simple Polygon p, which may be concave. If p has no area then the
+// zero vector is returned.
+func RandVecPolygon(p Polygon) VecGen {
+	return globalRng.RandVecPolygon(p)
+}
+
+// MixVec returns a VecGen that calls one of gens, chosen at random each time with a
+// chance proportional to the corresponding weight. If all weights are 0 then each is
+// equally likely. gens and weights must be the same length. If they are empty then the zero
+// vector is returned.
+func MixVec(gens []VecGen, weights []float64) VecGen {
+	return globalRng.MixVec(gens, weights)
+}
+
+// SumVec returns a VecGen that adds the results of all of gens.
+func SumVec(gens ...VecGen) VecGen {
+	return func() Vec {
+		var sum Vec
+		for _, g := range gens {
+			sum.Add(g())
+		}
+		return sum
 	}
-	areas := make([]float64, len(rects))
-	for i := range rects {
-		areas[i] = rects[i].Area()
+}
+
+// RotateVec returns a VecGen that rotates the result of gen (counterclockwise in screen
+// coordinates) by the radians given by rad. For example, to aim a spray of particles in
+// the direction a ship is facing:
+//
+//	RotateVec(RandVecArc(50, 100, -0.2, 0.2), func() float64 { return ship.Angle })
+func RotateVec(gen VecGen, rad NumGen) VecGen {
+	return func() Vec {
+		return gen().Rotated(rad())
 	}
+}
+
+// ScaleVec returns a VecGen that multiplies the result of gen by factor.
+func ScaleVec(gen VecGen, factor NumGen) VecGen {
 	return func() Vec {
-		rect := rects[wrand.SelectIndex(areas)]
-		return Vec{
-			X: rand.Float64()*rect.W + rect.X,
-			Y: rand.Float64()*rect.H + rect.Y,
+		return gen().Times(factor())
+	}
+}
+
+// TransformVec returns a VecGen that transforms the result of gen by t, which can stretch,
+// skew or flip it in ways that RotateVec and ScaleVec can't.
+func TransformVec(gen VecGen, t Transform) VecGen {
+	return func() Vec {
+		return t.Apply(gen())
+	}
+}
+
+// MirrorVec returns a VecGen that alternates between calling gen and returning the
+// reflection of the previous result across the line through the origin in the direction
+// axis. Emitting from it in pairs gives perfectly symmetric effects. If axis is the zero
+// vector then the previous result is reflected through the origin instead.
+func MirrorVec(gen VecGen, axis Vec) VecGen {
+	if axis != (Vec{}) {
+		axis.Normalize()
+	}
+	var last Vec
+	mirror := false
+	return func() Vec {
+		mirror = !mirror
+		if !mirror {
+			return axis.Times(2 * last.Dot(axis)).Minus(last)
 		}
+		last = gen()
+		return last
 	}
 }
 
-// Returns a uniformaly distributed radius between minR and maxR.
-func circleRadius(minR, maxR float64) float64 {
-	if maxR == 0 || maxR == minR {
-		return maxR
+// CycleVec returns a VecGen that returns each of points in order, starting over after the
+// last one. It can be used with GridVecs, SpiralVecs and FibonacciVecs to emit from fixed
+// points, or see ShuffleVecs for a random order. If points is empty then the zero vector
+// is returned.
+func CycleVec(points ...Vec) VecGen {
+	i := -1
+	return func() Vec {
+		if len(points) == 0 {
+			return Vec{}
+		}
+		i = (i + 1) % len(points)
+		return points[i]
+	}
+}
+
+// GridVecs returns the centers of the cells of r divided into cols columns and rows rows,
+// row by row from the top left. It returns nil if cols or rows is not positive.
+func GridVecs(r Rect, cols, rows int) []Vec {
+	if cols <= 0 || rows <= 0 {
+		return nil
+	}
+	points := make([]Vec, 0, cols*rows)
+	w, h := r.W/float64(cols), r.H/float64(rows)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			points = append(points, Vec{X: r.X + (float64(x)+0.5)*w, Y: r.Y + (float64(y)+0.5)*h})
+		}
+	}
+	return points
+}
+
+// SpiralVecs returns n points along a spiral out from the origin, turning counterclockwise
+// in screen coordinates. The arms of the spiral are spacing apart and the points along it
+// are about spacing apart. It returns nil if n or spacing is not positive.
+func SpiralVecs(n int, spacing float64) []Vec {
+	if n <= 0 || spacing <= 0 {
+		return nil
+	}
+	points := make([]Vec, n)
+	// The spiral has radius b*rad and each step turns far enough to move about spacing.
+	b := spacing / (2 * math.Pi)
+	rad := 0.0
+	for i := range points {
+		points[i] = Vec{X: b * rad}.Rotated(rad)
+		rad += spacing / math.Hypot(b*rad, b)
+	}
+	return points
+}
+
+// FibonacciVecs returns n points evenly spread within a circle of the given radius around
+// the origin, arranged like the seeds of a sunflower. Unlike random points they never
+// clump together. It returns nil if n is not positive.
+func FibonacciVecs(n int, radius float64) []Vec {
+	if n <= 0 {
+		return nil
+	}
+	points := make([]Vec, n)
+	golden := math.Pi * (3 - math.Sqrt(5))
+	for i := range points {
+		r := radius * math.Sqrt((float64(i)+0.5)/float64(n))
+		points[i] = Vec{X: r}.Rotated(float64(i) * golden)
 	}
-	unitMin := minR / maxR
-	unitMin *= unitMin
-	return math.Sqrt(rand.Float64()*(1-unitMin)+unitMin) * maxR
+	return points
 }
@@ -533,3 +533,135 @@ func TestRandVecRects(t *testing.T) {
 		}
 	}
 }
+
+func TestRandVecSegment(t *testing.T) {
+	s := Segment{A: Vec{X: 1, Y: 2}, B: Vec{X: 11, Y: -3}}
+	gen := RandVecSegment(s)
+	for i := 0; i < 1000; i++ {
+		if v := gen(); s.Dist(v) > e {
+			t.Errorf("trial %d: %#v is %v from the segment", i, v, s.Dist(v))
+		}
+	}
+}
+
+func TestRandVecCircleEdge(t *testing.T) {
+	gen := RandVecCircleEdge(5)
+	for i := 0; i < 1000; i++ {
+		if v := gen(); math.Abs(v.Len()-5) > e {
+			t.Errorf("trial %d: %#v has length %v", i, v, v.Len())
+		}
+	}
+}
+
+func TestRandVecPolygon(t *testing.T) {
+	// An L shape where the vertical part is twice the area of the horizontal part.
+	p := Polygon{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 5}, {X: 0, Y: 5}}
+	gen := RandVecPolygon(p)
+	trials, top := 30000, 0
+	for i := 0; i < trials; i++ {
+		v := gen()
+		if !p.CollidePoint(v.X, v.Y) && p.Dist(v) > e {
+			t.Fatalf("trial %d: %#v is outside", i, v)
+		}
+		if v.Y < 4 {
+			top++
+		}
+	}
+	if ratio := float64(top) / float64(trials); math.Abs(ratio-2.0/3) > 0.02 {
+		t.Errorf("got ratio %v, want about 2/3", ratio)
+	}
+
+	if v := RandVecPolygon(Polygon{{X: 1, Y: 1}, {X: 2, Y: 2}})(); v != (Vec{}) {
+		t.Errorf("got %#v from an empty polygon", v)
+	}
+}
+
+func TestVecGenCombinators(t *testing.T) {
+	one := StaticVec(Vec{X: 1, Y: 0})
+	cases := []struct {
+		gen  VecGen
+		want []Vec
+	}{
+		{SumVec(one, one, StaticVec(Vec{X: 0, Y: 2})), []Vec{{X: 2, Y: 2}}},
+		{SumVec(), []Vec{{}}},
+		{RotateVec(one, CycleNum(math.Pi/2, math.Pi)), []Vec{{X: 0, Y: -1}, {X: -1, Y: 0}}},
+		{ScaleVec(StaticVec(Vec{X: 1, Y: 2}), CycleNum(2, -1)), []Vec{{X: 2, Y: 4}, {X: -1, Y: -2}}},
+		{TransformVec(one, TranslateTransform(1, 2).Scaled(3, 1)), []Vec{{X: 4, Y: 2}}},
+		{MirrorVec(CycleVec(Vec{X: 1, Y: 2}, Vec{X: 3, Y: -1}), Vec{X: 0, Y: 5}),
+			[]Vec{{X: 1, Y: 2}, {X: -1, Y: 2}, {X: 3, Y: -1}, {X: -3, Y: -1}}},
+		{MirrorVec(StaticVec(Vec{X: 2, Y: 0}), Vec{X: 1, Y: 1}), []Vec{{X: 2, Y: 0}, {X: 0, Y: 2}}},
+		{MirrorVec(StaticVec(Vec{X: 2, Y: 1}), Vec{}), []Vec{{X: 2, Y: 1}, {X: -2, Y: -1}}},
+		{MixVec([]VecGen{one, StaticVec(Vec{})}, []float64{1, 0}), []Vec{{X: 1}, {X: 1}}},
+		{MixVec(nil, nil), []Vec{{}}},
+		{CycleVec(Vec{X: 1}, Vec{Y: 1}), []Vec{{X: 1}, {Y: 1}, {X: 1}}},
+		{CycleVec(), []Vec{{}, {}}},
+	}
+
+	for i, c := range cases {
+		for j, want := range c.want {
+			if got := c.gen(); !got.Equals(want, e) {
+				t.Errorf("case %d call %d: got %#v, want %#v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestFixedVecs(t *testing.T) {
+	grid := GridVecs(Rect{X: 10, Y: 20, W: 30, H: 20}, 3, 2)
+	want := []Vec{{X: 15, Y: 25}, {X: 25, Y: 25}, {X: 35, Y: 25}, {X: 15, Y: 35}, {X: 25, Y: 35}, {X: 35, Y: 35}}
+	if len(grid) != len(want) {
+		t.Fatalf("got %v, want %v", grid, want)
+	}
+	for i := range want {
+		if !grid[i].Equals(want[i], e) {
+			t.Errorf("grid %d: got %#v, want %#v", i, grid[i], want[i])
+		}
+	}
+
+	empty := [][]Vec{
+		GridVecs(Rect{W: 10, H: 10}, 0, 2),
+		GridVecs(Rect{W: 10, H: 10}, 2, -1),
+		SpiralVecs(-1, 3),
+		SpiralVecs(3, 0),
+		SpiralVecs(3, -2),
+		FibonacciVecs(-1, 10),
+	}
+	for i, points := range empty {
+		if points != nil {
+			t.Errorf("empty case %d: got %v", i, points)
+		}
+	}
+
+	spiral := SpiralVecs(200, 3)
+	if spiral[0] != (Vec{}) {
+		t.Errorf("spiral starts at %#v", spiral[0])
+	}
+	for i := 1; i < len(spiral); i++ {
+		if d := spiral[i].Dist(spiral[i-1]); d < 2.8 || d > 3.2 {
+			t.Errorf("spiral %d: %v from the last point", i, d)
+		}
+		if spiral[i].Len() <= spiral[i-1].Len() {
+			t.Errorf("spiral %d: doesn't move outward", i)
+		}
+	}
+
+	fib := FibonacciVecs(500, 10)
+	inner := 0
+	for i, v := range fib {
+		if v.Len() > 10 {
+			t.Errorf("fibonacci %d: %#v is outside the circle", i, v)
+		}
+		if v.Len() < 5 {
+			inner++
+		}
+		for j := 0; j < i; j++ {
+			if fib[j].Dist(v) < 0.5 {
+				t.Errorf("fibonacci %d: too close to %d", i, j)
+			}
+		}
+	}
+	// A quarter of the area is within half the radius.
+	if inner != 125 {
+		t.Errorf("got %d points within half the radius, want 125", inner)
+	}
+}
@@ -174,7 +174,7 @@ func Transform(display *ggweb.Surface, x, y float64) {
 	display.Save()
 	display.StyleColor(ggweb.Stroke, color.RGBA{255, 100, 255, 255})
 	display.SetLineWidth(3)
-	display.Transform(1, 0, 1, 1, x+40, y)
+	display.Transform(geo.Transform{A: 1, B: 0, C: 1, D: 1, E: x + 40, F: y})
 	display.DrawRect(ggweb.Stroke, geo.Rect{X: 0, Y: 0, W: 30, H: 30})
 	display.Restore()
 }
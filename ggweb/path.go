@@ -92,6 +92,50 @@ func (p *Path) BezierCurveTo(cp1x, cp1y, cp2x, cp2y, x, y float64) {
 	p.obj.Call("bezierCurveTo", cp1x, cp1y, cp2x, cp2y, x, y)
 }
 
+// Polygon adds the outline of poly to the path as a closed shape.
+func (p *Path) Polygon(poly geo.Polygon) {
+	if len(poly) == 0 {
+		return
+	}
+	p.MoveTo(poly[0].X, poly[0].Y)
+	for _, v := range poly[1:] {
+		p.LineTo(v.X, v.Y)
+	}
+	p.Close()
+}
+
+// Polygons adds each of polys to the path as a closed shape. The results of the geo
+// boolean functions, such as geo.PolygonDifference, wind holes the opposite way from
+// the outer boundaries so they are left empty when filled.
+func (p *Path) Polygons(polys []geo.Polygon) {
+	for _, poly := range polys {
+		p.Polygon(poly)
+	}
+}
+
+// QuadraticBezier moves to the start of b and adds it to the path.
+func (p *Path) QuadraticBezier(b geo.QuadraticBezier) {
+	p.MoveTo(b.P0.X, b.P0.Y)
+	p.QuadraticCurveTo(b.P1.X, b.P1.Y, b.P2.X, b.P2.Y)
+}
+
+// CubicBezier moves to the start of b and adds it to the path.
+func (p *Path) CubicBezier(b geo.CubicBezier) {
+	p.MoveTo(b.P0.X, b.P0.Y)
+	p.BezierCurveTo(b.P1.X, b.P1.Y, b.P2.X, b.P2.Y, b.P3.X, b.P3.Y)
+}
+
+// Spline moves to the start of s and adds each of its curves to the path.
+func (p *Path) Spline(s geo.Spline) {
+	if len(s) == 0 {
+		return
+	}
+	p.MoveTo(s[0].P0.X, s[0].P0.Y)
+	for _, b := range s {
+		p.BezierCurveTo(b.P1.X, b.P1.Y, b.P2.X, b.P2.Y, b.P3.X, b.P3.Y)
+	}
+}
+
 // Close draws a line to the start of the last continous line.
 func (p *Path) Close() {
 	p.obj.Call("closePath")
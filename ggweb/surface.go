@@ -17,6 +17,8 @@ import (
 type Surface struct {
 	Canvas *js.Object
 	Ctx    *js.Object
+	// transforms mirrors the context's save stack, the last one is the current transform.
+	transforms []geo.Transform
 }
 
 // NewSurface creates a new Surface with the given dimensions.
@@ -78,6 +80,7 @@ func (s *Surface) Rect() geo.Rect {
 func (s *Surface) SetSize(w, h int) {
 	s.Canvas.Set("width", w)
 	s.Canvas.Set("height", h)
+	s.transforms = nil
 }
 
 // Blit draws the source surface to s with source's top left corner at x, y.
@@ -96,11 +99,23 @@ func (s *Surface) BlitArea(source *Surface, area geo.Rect, x, y float64) {
 // Save saves the current context.
 func (s *Surface) Save() {
 	s.Ctx.Call("save")
+	s.transforms = append(s.transforms, *s.transform())
 }
 
 // Restore restores the last saved context.
 func (s *Surface) Restore() {
 	s.Ctx.Call("restore")
+	if len(s.transforms) > 1 {
+		s.transforms = s.transforms[:len(s.transforms)-1]
+	}
+}
+
+// transform returns the current transform, initializing it if needed.
+func (s *Surface) transform() *geo.Transform {
+	if len(s.transforms) == 0 {
+		s.transforms = []geo.Transform{geo.IdentityTransform()}
+	}
+	return &s.transforms[len(s.transforms)-1]
 }
 
 // StyleColor sets the fill/stoke to a solid color.
@@ -221,37 +236,51 @@ func (s *Surface) ClipPath(p *Path) {
 
 // Translate moves the orgin by the distances given.
 func (s *Surface) Translate(x, y float64) {
-	s.Ctx.Call("translate", math.Floor(x), math.Floor(y))
+	x, y = math.Floor(x), math.Floor(y)
+	s.Ctx.Call("translate", x, y)
+	s.transform().Translate(x, y)
 }
 
 // Rotate rotates the surface conterclockwise around the current origin.
 func (s *Surface) Rotate(radians float64) {
 	s.Ctx.Call("rotate", 2*math.Pi-radians)
+	s.transform().Rotate(radians)
 }
 
 // Scale changes the scale of the surface. 1.0 keeps the current size, smaller values
 // shrink and larger grow.
 func (s *Surface) Scale(x, y float64) {
 	s.Ctx.Call("scale", x, y)
+	s.transform().Scale(x, y)
 }
 
-// Transform multiplies the current transformation matrix by the one described by the
-// parameters:
-//  [ a c e ]
-//  [ b d f ]
-//  [ 0 0 1 ]
-func (s *Surface) Transform(a, b, c, d, e, f float64) {
-	s.Ctx.Call("transform", a, b, c, d, e, f)
+// Transform multiplies the current transformation matrix by t.
+func (s *Surface) Transform(t geo.Transform) {
+	s.Ctx.Call("transform", t.A, t.B, t.C, t.D, t.E, t.F)
+	s.transform().Mul(t)
 }
 
 // SetTransform resets the transformation matrix then applies the one given.
-func (s *Surface) SetTransform(a, b, c, d, e, f float64) {
-	s.Ctx.Call("setTransform", a, b, c, d, e, f)
+func (s *Surface) SetTransform(t geo.Transform) {
+	s.Ctx.Call("setTransform", t.A, t.B, t.C, t.D, t.E, t.F)
+	*s.transform() = t
 }
 
 // ResetTransform resets the transformation to the identy matrix.
 func (s *Surface) ResetTransform() {
 	s.Ctx.Call("resetTransform")
+	*s.transform() = geo.IdentityTransform()
+}
+
+// CurrentTransform returns the current transformation matrix. This is tracked on the Go
+// side, so it will be wrong if the context is transformed directly through Ctx. To map a
+// point on the canvas, such as the mouse position, back into the coordinates being drawn
+// in use the inverse:
+//
+//	inv, _ := s.CurrentTransform().Inverse()
+//	worldPos := inv.Apply(ggweb.MousePos())
+func (s *Surface) CurrentTransform() geo.Transform {
+	return *s.transform()
 }
 
 // SetFont sets the font style.
@@ -0,0 +1,104 @@
+package noise
+
+import (
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// FBM (fractional Brownian motion) adds several octaves of a Field together, each at a
+// higher frequency and lower amplitude than the last, to get detail at many scales.
+type FBM struct {
+	// Field is the noise to layer.
+	Field Field
+	// Octaves is the number of layers. Less than 1 is treated as 1.
+	Octaves int
+	// Frequency is the frequency of the first octave.
+	Frequency float64
+	// Lacunarity is how much the frequency is multiplied by for each octave.
+	Lacunarity float64
+	// Persistence is how much the amplitude is multiplied by for each octave.
+	Persistence float64
+}
+
+// NewFBM returns an FBM of field with the given number of octaves, a Frequency of 1,
+// Lacunarity of 2 and Persistence of 0.5.
+func NewFBM(field Field, octaves int) *FBM {
+	return &FBM{
+		Field:       field,
+		Octaves:     octaves,
+		Frequency:   1,
+		Lacunarity:  2,
+		Persistence: 0.5,
+	}
+}
+
+// At returns the sum of the octaves at (x, y, z) normalized to stay in the same range as
+// Field.
+func (f *FBM) At(x, y, z float64) float64 {
+	total, amp, max, freq := 0.0, 1.0, 0.0, f.Frequency
+	for i := 0; i < f.Octaves || i == 0; i++ {
+		// Offset each octave so that they don't all line up at the origin.
+		o := float64(i) * 31.7
+		total += f.Field(x*freq+o, y*freq+o, z*freq+o) * amp
+		max += amp
+		amp *= f.Persistence
+		freq *= f.Lacunarity
+	}
+	return total / max
+}
+
+// TimeNum returns a geo.NumGen that smoothly wanders between min and max as the time
+// pointed to by t advances. speed is how many units of noise are covered per second, so
+// higher values change faster.
+func TimeNum(f Field, t *time.Duration, speed, min, max float64) geo.NumGen {
+	return func() float64 {
+		n := f(t.Seconds()*speed, 0, 0)
+		return min + (n+1)/2*(max-min)
+	}
+}
+
+// TimeVec returns a geo.VecGen whose components smoothly wander between -scale and scale
+// as the time pointed to by t advances. This is useful for camera shake. speed is how many
+// units of noise are covered per second, so higher values change faster.
+func TimeVec(f Field, t *time.Duration, speed, scale float64) geo.VecGen {
+	return func() geo.Vec {
+		s := t.Seconds() * speed
+		// Sample far apart for each component so they are independent.
+		return geo.Vec{X: f(s, 0, 0), Y: f(s, 1000.5, 0)}.Times(scale)
+	}
+}
+
+// Flow is a 2D vector field made from noise that changes over time, such as wind or
+// turbulence.
+type Flow struct {
+	// Field is the noise the Flow is made from.
+	Field Field
+	// Scale converts positions to noise coordinates. Smaller values give larger features.
+	Scale float64
+	// Speed is how many units of noise the Flow moves through per second.
+	Speed float64
+	// Strength is the maximum length of the vectors in each direction.
+	Strength float64
+}
+
+// At returns the vector at pos at time t.
+func (f *Flow) At(pos geo.Vec, t time.Duration) geo.Vec {
+	x, y, z := pos.X*f.Scale, pos.Y*f.Scale, t.Seconds()*f.Speed
+	return geo.Vec{X: f.Field(x, y, z), Y: f.Field(x+1000.5, y+1000.5, z)}.Times(f.Strength)
+}
+
+// VecGen returns a geo.VecGen that samples the Flow at the position and time pointed to by
+// pos and t. To apply it as a force to each particle in a particle.System:
+//
+//	var pos geo.Vec
+//	wind := flow.VecGen(&pos, &now)
+//	system.ForEachParticle(func(p *particle.SystemParticle) {
+//		pos = p.Pos
+//		p.ApplyForce(wind())
+//	})
+func (f *Flow) VecGen(pos *geo.Vec, t *time.Duration) geo.VecGen {
+	return func() geo.Vec {
+		return f.At(*pos, *t)
+	}
+}
@@ -0,0 +1,141 @@
+// Package noise generates coherent gradient noise, which varies smoothly unlike uniform
+// random numbers. It is useful for terrain, camera shake, wind and anything else that
+// should look natural. The values returned are roughly in the range [-1, 1].
+package noise
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Field is a 3D noise function. Noise.Perlin3, Noise.Simplex3 and FBM.At are all Fields.
+// Lower dimensional noise can be had by holding the other coordinates constant.
+type Field func(x, y, z float64) float64
+
+// Noise is a seeded source of Perlin and simplex noise. The same seed always produces the
+// same noise. Use New to create one.
+type Noise struct {
+	perm [256]uint8
+}
+
+// New creates a Noise from the given seed.
+func New(seed int64) *Noise {
+	n := Noise{}
+	p := rand.New(rand.NewSource(seed)).Perm(256)
+	for i := range n.perm {
+		n.perm[i] = uint8(p[i])
+	}
+	return &n
+}
+
+func (n *Noise) hash(i int) int {
+	return int(n.perm[i&255])
+}
+
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+func grad1(hash int, x float64) float64 {
+	// Slopes of 1 through 8 in either direction.
+	g := float64(hash&7) + 1
+	if hash&8 != 0 {
+		g = -g
+	}
+	return g * x
+}
+
+func grad2(hash int, x, y float64) float64 {
+	switch hash & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
+
+func grad3(hash int, x, y, z float64) float64 {
+	// The 12 edges of a cube, with 4 repeated to make 16.
+	switch hash & 15 {
+	case 0, 12:
+		return x + y
+	case 1, 13:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x + z
+	case 5:
+		return -x + z
+	case 6:
+		return x - z
+	case 7:
+		return -x - z
+	case 8:
+		return y + z
+	case 9, 14:
+		return -y + z
+	case 10:
+		return y - z
+	default:
+		return -y - z
+	}
+}
+
+// Perlin1 returns 1D Perlin noise at x.
+func (n *Noise) Perlin1(x float64) float64 {
+	xf := math.Floor(x)
+	xi := int(xf)
+	x -= xf
+	// Max slope is 8 and the max of the blended ramps is 1/2.
+	return lerp(fade(x), grad1(n.hash(xi), x), grad1(n.hash(xi+1), x-1)) / 4
+}
+
+// Perlin2 returns 2D Perlin noise at (x, y).
+func (n *Noise) Perlin2(x, y float64) float64 {
+	xf, yf := math.Floor(x), math.Floor(y)
+	xi, yi := int(xf), int(yf)
+	x, y = x-xf, y-yf
+	u, v := fade(x), fade(y)
+	a, b := n.hash(xi)+yi, n.hash(xi+1)+yi
+	return lerp(v,
+		lerp(u, grad2(n.hash(a), x, y), grad2(n.hash(b), x-1, y)),
+		lerp(u, grad2(n.hash(a+1), x, y-1), grad2(n.hash(b+1), x-1, y-1)))
+}
+
+// Perlin3 returns 3D Perlin noise at (x, y, z).
+func (n *Noise) Perlin3(x, y, z float64) float64 {
+	xf, yf, zf := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(xf), int(yf), int(zf)
+	x, y, z = x-xf, y-yf, z-zf
+	u, v, w := fade(x), fade(y), fade(z)
+	a := n.hash(xi) + yi
+	aa, ab := n.hash(a)+zi, n.hash(a+1)+zi
+	b := n.hash(xi+1) + yi
+	ba, bb := n.hash(b)+zi, n.hash(b+1)+zi
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad3(n.hash(aa), x, y, z), grad3(n.hash(ba), x-1, y, z)),
+			lerp(u, grad3(n.hash(ab), x, y-1, z), grad3(n.hash(bb), x-1, y-1, z))),
+		lerp(v,
+			lerp(u, grad3(n.hash(aa+1), x, y, z-1), grad3(n.hash(ba+1), x-1, y, z-1)),
+			lerp(u, grad3(n.hash(ab+1), x, y-1, z-1), grad3(n.hash(bb+1), x-1, y-1, z-1))))
+}
@@ -0,0 +1,115 @@
+package noise
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+func TestDeterministic(t *testing.T) {
+	a, b, c := New(1), New(1), New(2)
+	same := true
+	for i := 0; i < 100; i++ {
+		x, y, z := float64(i)*0.37, float64(i)*0.11, float64(i)*0.23
+		if a.Perlin3(x, y, z) != b.Perlin3(x, y, z) || a.Simplex2(x, y) != b.Simplex2(x, y) {
+			t.Fatalf("same seed gave different noise at %d", i)
+		}
+		if a.Perlin3(x, y, z) != c.Perlin3(x, y, z) {
+			same = false
+		}
+	}
+	if same {
+		t.Errorf("different seeds gave the same noise")
+	}
+}
+
+func TestRangeAndContinuity(t *testing.T) {
+	n := New(42)
+	fields := map[string]Field{
+		"Perlin1":  func(x, y, z float64) float64 { return n.Perlin1(x) },
+		"Perlin2":  func(x, y, z float64) float64 { return n.Perlin2(x, y) },
+		"Perlin3":  n.Perlin3,
+		"Simplex2": func(x, y, z float64) float64 { return n.Simplex2(x, y) },
+		"Simplex3": n.Simplex3,
+		"FBM":      NewFBM(n.Perlin3, 5).At,
+	}
+
+	const d = 1e-4
+	for name, f := range fields {
+		min, max := math.Inf(1), math.Inf(-1)
+		for i := 0; i < 5000; i++ {
+			x, y, z := float64(i)*0.0731-100, float64(i)*0.0419-50, float64(i)*0.0173
+			v := f(x, y, z)
+			min, max = math.Min(min, v), math.Max(max, v)
+			if diff := math.Abs(f(x+d, y+d, z+d) - v); diff > 0.01 {
+				t.Errorf("%s: jump of %v at (%v, %v, %v)", name, diff, x, y, z)
+				break
+			}
+		}
+		if min < -1.05 || max > 1.05 {
+			t.Errorf("%s: range [%v, %v] outside of [-1, 1]", name, min, max)
+		}
+		if max-min < 0.5 {
+			t.Errorf("%s: range [%v, %v] is too small", name, min, max)
+		}
+	}
+}
+
+func TestPerlinLattice(t *testing.T) {
+	n := New(7)
+	for i := -5; i <= 5; i++ {
+		x := float64(i)
+		if got := n.Perlin1(x); got != 0 {
+			t.Errorf("Perlin1(%v) = %v, want 0", x, got)
+		}
+		if got := n.Perlin2(x, x+3); got != 0 {
+			t.Errorf("Perlin2(%v, %v) = %v, want 0", x, x+3, got)
+		}
+		if got := n.Perlin3(x, -x, 2*x); got != 0 {
+			t.Errorf("Perlin3(%v, %v, %v) = %v, want 0", x, -x, 2*x, got)
+		}
+	}
+}
+
+func TestTimeGen(t *testing.T) {
+	n := New(3)
+	var now time.Duration
+	num := TimeNum(n.Perlin3, &now, 1, 10, 20)
+	vec := TimeVec(n.Perlin3, &now, 1, 5)
+
+	first := vec()
+	changed := false
+	for i := 0; i < 100; i++ {
+		now += 100 * time.Millisecond
+		if v := num(); v < 10 || v > 20 {
+			t.Errorf("TimeNum: got %v, want in [10, 20]", v)
+		}
+		v := vec()
+		if math.Abs(v.X) > 5 || math.Abs(v.Y) > 5 {
+			t.Errorf("TimeVec: got %#v, want components in [-5, 5]", v)
+		}
+		if v != first {
+			changed = true
+		}
+	}
+	if !changed {
+		t.Errorf("TimeVec didn't change with time")
+	}
+}
+
+func TestFlow(t *testing.T) {
+	f := Flow{Field: New(5).Simplex3, Scale: 0.01, Speed: 1, Strength: 10}
+	var pos geo.Vec
+	var now time.Duration
+	gen := f.VecGen(&pos, &now)
+
+	pos, now = geo.Vec{X: 123, Y: 45}, 1500*time.Millisecond
+	if got, want := gen(), f.At(pos, now); got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	if a, b := f.At(pos, now), f.At(pos.Plus(geo.Vec{X: 0.1}), now); a.Minus(b).Len() > 0.1 {
+		t.Errorf("flow isn't smooth: %#v vs %#v", a, b)
+	}
+}
@@ -0,0 +1,89 @@
+package noise
+
+import "math"
+
+// Skewing factors for turning the simplex grid into a square grid and back.
+var (
+	skew2   = 0.5 * (math.Sqrt(3) - 1)
+	unskew2 = (3 - math.Sqrt(3)) / 6
+)
+
+const (
+	skew3   = 1.0 / 3
+	unskew3 = 1.0 / 6
+)
+
+// Simplex2 returns 2D simplex noise at (x, y). It is a little faster than Perlin2 and has
+// fewer directional artifacts.
+func (n *Noise) Simplex2(x, y float64) float64 {
+	s := (x + y) * skew2
+	i, j := math.Floor(x+s), math.Floor(y+s)
+	t := (i + j) * unskew2
+	x0, y0 := x-(i-t), y-(j-t)
+
+	// Which of the two triangles in the square are we in.
+	i1, j1 := 0, 1
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+	x1, y1 := x0-float64(i1)+unskew2, y0-float64(j1)+unskew2
+	x2, y2 := x0-1+2*unskew2, y0-1+2*unskew2
+
+	ii, jj := int(i), int(j)
+	corner := func(h int, x, y float64) float64 {
+		t := 0.5 - x*x - y*y
+		if t < 0 {
+			return 0
+		}
+		t *= t
+		return t * t * grad2(h, x, y)
+	}
+	total := corner(n.hash(ii+n.hash(jj)), x0, y0) +
+		corner(n.hash(ii+i1+n.hash(jj+j1)), x1, y1) +
+		corner(n.hash(ii+1+n.hash(jj+1)), x2, y2)
+	return 70 * total
+}
+
+// Simplex3 returns 3D simplex noise at (x, y, z). It is a little faster than Perlin3 and
+// has fewer directional artifacts.
+func (n *Noise) Simplex3(x, y, z float64) float64 {
+	s := (x + y + z) * skew3
+	i, j, k := math.Floor(x+s), math.Floor(y+s), math.Floor(z+s)
+	t := (i + j + k) * unskew3
+	x0, y0, z0 := x-(i-t), y-(j-t), z-(k-t)
+
+	// Which of the six tetrahedra in the cube are we in.
+	var i1, j1, k1, i2, j2, k2 int
+	switch {
+	case x0 >= y0 && y0 >= z0:
+		i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+	case x0 >= y0 && x0 >= z0:
+		i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+	case x0 >= y0:
+		i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+	case y0 < z0:
+		i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+	case x0 < z0:
+		i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+	default:
+		i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+	}
+	x1, y1, z1 := x0-float64(i1)+unskew3, y0-float64(j1)+unskew3, z0-float64(k1)+unskew3
+	x2, y2, z2 := x0-float64(i2)+2*unskew3, y0-float64(j2)+2*unskew3, z0-float64(k2)+2*unskew3
+	x3, y3, z3 := x0-1+3*unskew3, y0-1+3*unskew3, z0-1+3*unskew3
+
+	ii, jj, kk := int(i), int(j), int(k)
+	corner := func(h int, x, y, z float64) float64 {
+		t := 0.6 - x*x - y*y - z*z
+		if t < 0 {
+			return 0
+		}
+		t *= t
+		return t * t * grad3(h, x, y, z)
+	}
+	total := corner(n.hash(ii+n.hash(jj+n.hash(kk))), x0, y0, z0) +
+		corner(n.hash(ii+i1+n.hash(jj+j1+n.hash(kk+k1))), x1, y1, z1) +
+		corner(n.hash(ii+i2+n.hash(jj+j2+n.hash(kk+k2))), x2, y2, z2) +
+		corner(n.hash(ii+1+n.hash(jj+1+n.hash(kk+1))), x3, y3, z3)
+	return 32 * total
+}
@@ -0,0 +1,146 @@
+package pack
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Heuristic chooses between the free spaces a MaxRects could put a rectangle in.
+type Heuristic int
+
+const (
+	// BestShortSideFit picks the space where the smaller of the leftover width and height
+	// is the smallest. It is usually the best choice.
+	BestShortSideFit Heuristic = iota
+	// BestLongSideFit picks the space where the larger of the leftover width and height is
+	// the smallest.
+	BestLongSideFit
+	// BestAreaFit picks the smallest space.
+	BestAreaFit
+	// BottomLeft picks the space that keeps the rectangle's bottom edge the highest, like
+	// Tetris. It fills from the top of the bin down.
+	BottomLeft
+)
+
+// MaxRects is a Packer that keeps track of every maximal free rectangle in the bin, so it
+// can find the best space for each insertion. It packs the tightest, but the number of
+// free rectangles, and so the time to insert, grows with the number inserted. Use
+// NewMaxRects to create one.
+type MaxRects struct {
+	// Heuristic is how the space for each rectangle is chosen.
+	Heuristic Heuristic
+	opts      Options
+	w, h      float64
+	free      []geo.Rect
+	used      float64
+}
+
+var _ Packer = (*MaxRects)(nil)
+
+// NewMaxRects creates an empty MaxRects with a bin of size w by h that uses the
+// BestShortSideFit Heuristic.
+func NewMaxRects(w, h float64, opts Options) *MaxRects {
+	m := &MaxRects{opts: opts, w: w, h: h}
+	m.Reset()
+	return m
+}
+
+// Reset removes everything that was inserted.
+func (m *MaxRects) Reset() {
+	// The padding on the right and bottom of the last rectangles can hang off the edge.
+	m.free = []geo.Rect{{W: m.w + m.opts.Padding, H: m.h + m.opts.Padding}}
+	m.used = 0
+}
+
+// Occupancy returns the fraction of the bin's area covered by inserted rectangles, not
+// counting padding and extrusion.
+func (m *MaxRects) Occupancy() float64 {
+	return m.used / (m.w * m.h)
+}
+
+// Insert finds a place for a rectangle of size w by h. ok is false if there is no room
+// left for it, in which case nothing changes.
+func (m *MaxRects) Insert(w, h float64) (p Placement, ok bool) {
+	rw, rh := m.opts.reserve(w, h)
+	best, bestScore := geo.Rect{}, [2]float64{math.Inf(1), math.Inf(1)}
+	rotated := false
+	try := func(f geo.Rect, w, h float64, rot bool) {
+		if w > f.W || h > f.H {
+			return
+		}
+		s := m.score(f, w, h)
+		if s[0] < bestScore[0] || s[0] == bestScore[0] && s[1] < bestScore[1] {
+			best, bestScore, rotated, ok = geo.Rect{X: f.X, Y: f.Y, W: w, H: h}, s, rot, true
+		}
+	}
+	for _, f := range m.free {
+		try(f, rw, rh, false)
+		if m.opts.AllowRotation && w != h {
+			try(f, rh, rw, true)
+		}
+	}
+	if !ok {
+		return Placement{}, false
+	}
+
+	m.split(best)
+	m.used += w * h
+	return m.opts.placement(best, rotated), true
+}
+
+// score returns how well a rectangle of size w by h fits in the free rectangle f, lower
+// is better with the second number breaking ties.
+func (m *MaxRects) score(f geo.Rect, w, h float64) [2]float64 {
+	leftW, leftH := f.W-w, f.H-h
+	short, long := math.Min(leftW, leftH), math.Max(leftW, leftH)
+	switch m.Heuristic {
+	case BestLongSideFit:
+		return [2]float64{long, short}
+	case BestAreaFit:
+		return [2]float64{f.W*f.H - w*h, short}
+	case BottomLeft:
+		return [2]float64{f.Y + h, f.X}
+	}
+	return [2]float64{short, long}
+}
+
+// split removes used from the free rectangles, replacing each one it overlaps with the
+// up to four largest rectangles around used, then removes any free rectangle that is
+// inside another.
+func (m *MaxRects) split(used geo.Rect) {
+	free := make([]geo.Rect, 0, len(m.free)+4)
+	for _, f := range m.free {
+		if !used.CollideRect(f) {
+			free = append(free, f)
+			continue
+		}
+		if used.X > f.X {
+			free = append(free, geo.Rect{X: f.X, Y: f.Y, W: used.X - f.X, H: f.H})
+		}
+		if used.Right() < f.Right() {
+			free = append(free, geo.Rect{X: used.Right(), Y: f.Y, W: f.Right() - used.Right(), H: f.H})
+		}
+		if used.Y > f.Y {
+			free = append(free, geo.Rect{X: f.X, Y: f.Y, W: f.W, H: used.Y - f.Y})
+		}
+		if used.Bottom() < f.Bottom() {
+			free = append(free, geo.Rect{X: f.X, Y: used.Bottom(), W: f.W, H: f.Bottom() - used.Bottom()})
+		}
+	}
+
+	m.free = make([]geo.Rect, 0, len(free))
+	for i, f := range free {
+		contained := false
+		for j, other := range free {
+			// Of two identical rectangles keep the first.
+			if i != j && other.Contains(f) && (other != f || j < i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			m.free = append(m.free, f)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package pack places rectangles inside a fixed size bin without overlapping, such as
+// sprites in a texture atlas or widgets in a panel. Rectangles are inserted one at a time
+// and stay where they are put, so more can be added later as long as there is room.
+//
+// Building an atlas at load time might look like:
+//
+//	p := pack.NewMaxRects(1024, 1024, pack.Options{AllowRotation: true, Padding: 1})
+//	for _, sprite := range sprites {
+//		place, ok := p.Insert(sprite.W, sprite.H)
+//		if !ok {
+//			// Start a new atlas.
+//		}
+//		...
+//	}
+//
+// MaxRects packs the tightest but gets slower as more is inserted. Skyline is faster and
+// suits rectangles of similar heights, such as glyphs of a font.
+package pack
+
+import "github.com/Bredgren/gogame/geo"
+
+// Packer is the interface shared by the packers in this package.
+type Packer interface {
+	// Insert finds a place for a rectangle of size w by h. ok is false if there is no room
+	// left for it, in which case nothing changes.
+	Insert(w, h float64) (p Placement, ok bool)
+	// Occupancy returns the fraction of the bin's area covered by inserted rectangles, not
+	// counting padding and extrusion.
+	Occupancy() float64
+	// Reset removes everything that was inserted.
+	Reset()
+}
+
+// Placement is where a rectangle was put in the bin.
+type Placement struct {
+	// Rect is the area given to the rectangle. If Rotated is true then its width and height
+	// are swapped from the size that was asked for.
+	Rect geo.Rect
+	// Rotated is true if the rectangle was turned 90 degrees to fit. For a sprite this
+	// means it should be drawn into the atlas rotated clockwise, and rotated back
+	// counterclockwise when drawn from it.
+	Rotated bool
+}
+
+// Options are the settings shared by the packers in this package.
+type Options struct {
+	// AllowRotation lets rectangles be turned 90 degrees if that fits better.
+	AllowRotation bool
+	// Padding is the empty space left between rectangles. There is no padding between
+	// rectangles and the edges of the bin.
+	Padding float64
+	// Extrude is the space reserved around each rectangle, inside the padding, for copies of
+	// the sprite's edge pixels. Filtering or rounding can sample slightly outside a sprite
+	// and this keeps it from picking up its neighbors. Placement.Rect doesn't include it.
+	Extrude float64
+}
+
+// reserve returns the space a rectangle of size w by h takes up with padding and
+// extrusion.
+func (o Options) reserve(w, h float64) (float64, float64) {
+	return w + 2*o.Extrude + o.Padding, h + 2*o.Extrude + o.Padding
+}
+
+// placement returns the Placement for a rectangle reserved at r.
+func (o Options) placement(r geo.Rect, rotated bool) Placement {
+	return Placement{
+		Rect: geo.Rect{
+			X: r.X + o.Extrude,
+			Y: r.Y + o.Extrude,
+			W: r.W - 2*o.Extrude - o.Padding,
+			H: r.H - 2*o.Extrude - o.Padding,
+		},
+		Rotated: rotated,
+	}
+}
@@ -0,0 +1,131 @@
+package pack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+type packerCase struct {
+	name string
+	new  func(w, h float64, opts Options) Packer
+}
+
+var packers = []packerCase{
+	{"MaxRects", func(w, h float64, opts Options) Packer { return NewMaxRects(w, h, opts) }},
+	{"MaxRectsBestAreaFit", func(w, h float64, opts Options) Packer {
+		m := NewMaxRects(w, h, opts)
+		m.Heuristic = BestAreaFit
+		return m
+	}},
+	{"MaxRectsBottomLeft", func(w, h float64, opts Options) Packer {
+		m := NewMaxRects(w, h, opts)
+		m.Heuristic = BottomLeft
+		return m
+	}},
+	{"Skyline", func(w, h float64, opts Options) Packer { return NewSkyline(w, h, opts) }},
+}
+
+func TestPackExact(t *testing.T) {
+	type insert struct {
+		w, h float64
+		ok   bool
+		want Placement
+	}
+	cases := []struct {
+		w, h    float64
+		opts    Options
+		inserts []insert
+	}{
+		{100, 100, Options{}, []insert{
+			{50, 50, true, Placement{Rect: geo.Rect{X: 0, Y: 0, W: 50, H: 50}}},
+			{50, 50, true, Placement{Rect: geo.Rect{X: 50, Y: 0, W: 50, H: 50}}},
+			{50, 50, true, Placement{Rect: geo.Rect{X: 0, Y: 50, W: 50, H: 50}}},
+			{50, 50, true, Placement{Rect: geo.Rect{X: 50, Y: 50, W: 50, H: 50}}},
+			{1, 1, false, Placement{}},
+		}},
+		{100, 10, Options{}, []insert{
+			{10, 100, false, Placement{}},
+			{101, 1, false, Placement{}},
+		}},
+		{100, 10, Options{AllowRotation: true}, []insert{
+			{10, 100, true, Placement{Rect: geo.Rect{X: 0, Y: 0, W: 100, H: 10}, Rotated: true}},
+		}},
+		{100, 100, Options{Padding: 2}, []insert{
+			{49, 100, true, Placement{Rect: geo.Rect{X: 0, Y: 0, W: 49, H: 100}}},
+			{50, 100, false, Placement{}},
+			{49, 100, true, Placement{Rect: geo.Rect{X: 51, Y: 0, W: 49, H: 100}}},
+		}},
+		{100, 100, Options{Padding: 2, Extrude: 1}, []insert{
+			{48, 98, true, Placement{Rect: geo.Rect{X: 1, Y: 1, W: 48, H: 98}}},
+			{46, 98, true, Placement{Rect: geo.Rect{X: 53, Y: 1, W: 46, H: 98}}},
+			{1, 1, false, Placement{}},
+		}},
+	}
+
+	for _, p := range packers {
+		for i, c := range cases {
+			packer := p.new(c.w, c.h, c.opts)
+			for j, in := range c.inserts {
+				got, ok := packer.Insert(in.w, in.h)
+				if ok != in.ok || got != in.want {
+					t.Errorf("%s case %d insert %d: got %#v %v, want %#v %v", p.name, i, j, got, ok, in.want, in.ok)
+				}
+			}
+		}
+	}
+}
+
+func TestPackRandom(t *testing.T) {
+	opts := []Options{
+		{},
+		{AllowRotation: true},
+		{AllowRotation: true, Padding: 1, Extrude: 2},
+	}
+	for _, p := range packers {
+		for i, o := range opts {
+			r := rand.New(rand.NewSource(1))
+			packer := p.new(512, 256, o)
+			placed := []Placement{}
+			area := 0.0
+			for tries := 0; tries < 1000; tries++ {
+				w, h := float64(4+r.Intn(40)), float64(4+r.Intn(20))
+				place, ok := packer.Insert(w, h)
+				if !ok {
+					continue
+				}
+				if rw, rh := place.Rect.W, place.Rect.H; place.Rotated && (rw != h || rh != w) ||
+					!place.Rotated && (rw != w || rh != h) {
+					t.Fatalf("%s opts %d: placed %v x %v as %#v", p.name, i, w, h, place)
+				}
+				reserved := place.Rect.Inflated(2*o.Extrude, 2*o.Extrude)
+				if !(geo.Rect{W: 512, H: 256}).Contains(reserved) {
+					t.Fatalf("%s opts %d: %#v is outside the bin", p.name, i, reserved)
+				}
+				for _, other := range placed {
+					if reserved.Inflated(o.Padding*2, o.Padding*2).CollideRect(other.Rect.Inflated(2*o.Extrude, 2*o.Extrude)) {
+						t.Fatalf("%s opts %d: %#v is too close to %#v", p.name, i, place.Rect, other.Rect)
+					}
+				}
+				placed = append(placed, place)
+				area += w * h
+			}
+			if got, want := packer.Occupancy(), area/(512*256); got != want {
+				t.Errorf("%s opts %d: got occupancy %v, want %v", p.name, i, got, want)
+			}
+			// These sizes should pack reasonably well, when padding doesn't waste space.
+			if o.Padding == 0 && o.Extrude == 0 && packer.Occupancy() < 0.8 {
+				t.Errorf("%s opts %d: occupancy only %v", p.name, i, packer.Occupancy())
+			}
+
+			packer.Reset()
+			if packer.Occupancy() != 0 {
+				t.Errorf("%s opts %d: occupancy %v after reset", p.name, i, packer.Occupancy())
+			}
+			if _, ok := packer.Insert(512-2*o.Extrude, 256-2*o.Extrude); !ok {
+				t.Errorf("%s opts %d: can't fill bin after reset", p.name, i)
+			}
+		}
+	}
+}
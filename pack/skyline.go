@@ -0,0 +1,124 @@
+package pack
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// segment is a horizontal piece of the skyline, the bottom of everything packed above it.
+type segment struct {
+	x, y, w float64
+}
+
+// Skyline is a Packer that only keeps track of the lowest edge of what has been packed,
+// like a city skyline hanging from the top of the bin. Each rectangle goes where its
+// bottom edge ends up the highest. It is fast and uses little memory, but space under an
+// overhang is never used, so it works best when rectangles have similar heights. Use
+// NewSkyline to create one.
+type Skyline struct {
+	opts    Options
+	w, h    float64
+	skyline []segment
+	used    float64
+}
+
+var _ Packer = (*Skyline)(nil)
+
+// NewSkyline creates an empty Skyline with a bin of size w by h.
+func NewSkyline(w, h float64, opts Options) *Skyline {
+	s := &Skyline{opts: opts, w: w, h: h}
+	s.Reset()
+	return s
+}
+
+// Reset removes everything that was inserted.
+func (s *Skyline) Reset() {
+	s.skyline = []segment{{w: s.w + s.opts.Padding}}
+	s.used = 0
+}
+
+// Occupancy returns the fraction of the bin's area covered by inserted rectangles, not
+// counting padding and extrusion.
+func (s *Skyline) Occupancy() float64 {
+	return s.used / (s.w * s.h)
+}
+
+// Insert finds a place for a rectangle of size w by h. ok is false if there is no room
+// left for it, in which case nothing changes.
+func (s *Skyline) Insert(w, h float64) (p Placement, ok bool) {
+	rw, rh := s.opts.reserve(w, h)
+	best, bestIndex := geo.Rect{}, -1
+	bestBottom, bestWidth := math.Inf(1), math.Inf(1)
+	rotated := false
+	try := func(i int, w, h float64, rot bool) {
+		y, ok := s.fit(i, w, h)
+		if !ok {
+			return
+		}
+		// Prefer the highest bottom edge, then the narrowest segment to keep gaps small.
+		if y+h < bestBottom || y+h == bestBottom && s.skyline[i].w < bestWidth {
+			best, bestIndex, rotated = geo.Rect{X: s.skyline[i].x, Y: y, W: w, H: h}, i, rot
+			bestBottom, bestWidth = y+h, s.skyline[i].w
+		}
+	}
+	for i := range s.skyline {
+		try(i, rw, rh, false)
+		if s.opts.AllowRotation && w != h {
+			try(i, rh, rw, true)
+		}
+	}
+	if bestIndex < 0 {
+		return Placement{}, false
+	}
+
+	s.add(bestIndex, best)
+	s.used += w * h
+	return s.opts.placement(best, rotated), true
+}
+
+// fit returns the y position for a rectangle of size w by h with its left edge at the start
+// of segment i, or false if it goes out of the bin.
+func (s *Skyline) fit(i int, w, h float64) (float64, bool) {
+	x := s.skyline[i].x
+	if x+w > s.w+s.opts.Padding {
+		return 0, false
+	}
+	y := 0.0
+	for j := i; j < len(s.skyline) && s.skyline[j].x < x+w; j++ {
+		y = math.Max(y, s.skyline[j].y)
+	}
+	if y+h > s.h+s.opts.Padding {
+		return 0, false
+	}
+	return y, true
+}
+
+// add puts r on the skyline starting at segment i.
+func (s *Skyline) add(i int, r geo.Rect) {
+	skyline := make([]segment, 0, len(s.skyline)+1)
+	skyline = append(skyline, s.skyline[:i]...)
+	skyline = append(skyline, segment{x: r.X, y: r.Bottom(), w: r.W})
+	for _, seg := range s.skyline[i:] {
+		// Trim the segments that are now under r.
+		if seg.x+seg.w <= r.Right() {
+			continue
+		}
+		if seg.x < r.Right() {
+			seg.w -= r.Right() - seg.x
+			seg.x = r.Right()
+		}
+		skyline = append(skyline, seg)
+	}
+
+	// Join neighbors at the same height.
+	s.skyline = skyline[:1]
+	for _, seg := range skyline[1:] {
+		last := &s.skyline[len(s.skyline)-1]
+		if last.y == seg.y {
+			last.w += seg.w
+			continue
+		}
+		s.skyline = append(s.skyline, seg)
+	}
+}
@@ -2,6 +2,7 @@ package particle
 
 import (
 	"math"
+	"math/rand"
 	"time"
 
 	"github.com/Bredgren/gogame/geo"
@@ -15,19 +16,52 @@ type SystemParticle struct {
 	inFreeList bool
 }
 
+// Burst is a group of particles that a System emits all at once at a scheduled time.
+type Burst struct {
+	// At is how long after the System starts that the Burst happens.
+	At time.Duration
+	// Count is the number of particles to emit.
+	Count int
+	// Interval, if greater than 0, repeats the Burst this often after At.
+	Interval time.Duration
+	// Probabilistic, if true, makes each repetition of the Burst happen with a probability of
+	// Chance. Otherwise it always happens.
+	Probabilistic bool
+	// Chance is the probability, from 0 to 1, that each repetition of a Probabilistic Burst
+	// happens. Values outside of that range are clamped, so 0 or less never happens.
+	Chance float64
+}
+
 // System is a manager for large groups of particles.
 type System struct {
-	// Rate is the number of new particles per second.
+	// Rate is the number of new particles per second. Fractions of a particle carry over
+	// between calls to Update so the average rate is exact for any frame rate.
 	Rate float64
-	// InitPos/Vel/Mass/Life are the starting parameters for each new particle.
-	InitPos      geo.VecGen
-	InitVel      geo.VecGen
-	InitMass     geo.NumGen
-	InitLife     time.Duration
-	pool         []SystemParticle
-	freeList     chan *SystemParticle
-	globalForce  geo.Vec
-	lastParticle time.Duration
+	// Bursts are emitted in addition to Rate at their scheduled times.
+	Bursts []Burst
+	// Pos is the location of the emitter, which is added to InitPos. If it moves between
+	// calls to Update then the particles emitted in between are spread along the way it
+	// moved.
+	Pos geo.Vec
+	// InitPos/Vel/Mass/Life are the starting parameters for each new particle. If the
+	// generators are made from a geo.Rng with a fixed seed then the System behaves exactly
+	// the same on every run given the same sequence of calls.
+	InitPos  geo.VecGen
+	InitVel  geo.VecGen
+	InitMass geo.NumGen
+	InitLife time.Duration
+	// Rng, if not nil, decides whether Probabilistic Bursts happen instead of the global
+	// source in math/rand that the geo package's random functions use.
+	Rng *geo.Rng
+
+	pool        []SystemParticle
+	freeList    chan *SystemParticle
+	globalForce geo.Vec
+	// owed is the fraction of a particle that Rate has accumulated but not yet emitted.
+	owed    float64
+	age     time.Duration
+	lastPos geo.Vec
+	started bool
 }
 
 // NewSystem initializes a particle system configured to contain at most size particles.
@@ -63,11 +97,19 @@ func (s *System) ForEachParticle(f func(p *SystemParticle)) {
 }
 
 // Update updates the state of all active particles and creates new particles if the limit
-// hasn't been reached yet. dt is the amount of time to simulate.
+// hasn't been reached yet. dt is the amount of time to simulate. New particles are created
+// at the moment within dt that they are due and then simulated for the rest of it, so they
+// don't clump together.
 func (s *System) Update(dt time.Duration) {
+	if !s.started {
+		s.lastPos = s.Pos
+		s.started = true
+	}
+
+	force := s.globalForce
 	for i := range s.pool {
 		if s.pool[i].Active {
-			s.pool[i].ApplyForce(s.globalForce)
+			s.pool[i].ApplyForce(force)
 			s.pool[i].Update(dt)
 			s.pool[i].Life -= dt
 			if s.pool[i].Life <= 0 {
@@ -84,19 +126,106 @@ func (s *System) Update(dt time.Duration) {
 		}
 	}
 
-	s.lastParticle += dt
-	newCount := int(math.Floor(s.Rate * s.lastParticle.Seconds()))
-	for newCount > 0 && len(s.freeList) > 0 {
-		newParticle := <-s.freeList
-		newParticle.inFreeList = false
-		newParticle.Active = true
-		newParticle.Life = s.InitLife
-		newParticle.Pos = s.InitPos()
-		newParticle.Vel = s.InitVel()
-		newParticle.Mass = s.InitMass()
-		s.lastParticle = 0
-		newCount--
+	// Particles that are due while the pool is full are dropped rather than saved up for
+	// when there is room.
+	if s.Rate > 0 {
+		start := s.owed
+		s.owed += s.Rate * dt.Seconds()
+		for n := 1; float64(n) <= s.owed; n++ {
+			at := time.Duration((float64(n) - start) / s.Rate * float64(time.Second))
+			s.spawn(at, dt, force)
+		}
+		s.owed -= math.Floor(s.owed)
+	}
+
+	for _, b := range s.Bursts {
+		for _, at := range b.times(s.age, s.age+dt) {
+			if !s.happens(&b) {
+				continue
+			}
+			for n := 0; n < b.Count; n++ {
+				s.spawn(at-s.age, dt, force)
+			}
+		}
+	}
+
+	s.age += dt
+	s.lastPos = s.Pos
+}
+
+// times returns when the Burst happens from start up to but not including end.
+func (b *Burst) times(start, end time.Duration) []time.Duration {
+	if b.Interval <= 0 {
+		if b.At >= start && b.At < end {
+			return []time.Duration{b.At}
+		}
+		return nil
+	}
+	t := b.At
+	if t < start {
+		// Skip to the first repetition at or after start.
+		t += (start - t + b.Interval - 1) / b.Interval * b.Interval
+	}
+	var times []time.Duration
+	for ; t < end; t += b.Interval {
+		times = append(times, t)
+	}
+	return times
+}
+
+// happens returns whether a repetition of b happens, taking its Chance into account if it
+// is Probabilistic.
+func (s *System) happens(b *Burst) bool {
+	switch {
+	case !b.Probabilistic || b.Chance >= 1:
+		return true
+	case b.Chance <= 0:
+		return false
+	case s.Rng != nil:
+		return s.Rng.Float64() < b.Chance
+	}
+	return rand.Float64() < b.Chance
+}
+
+// Emit immediately creates n new particles at Pos, or as many as there is room for, and
+// returns how many it created.
+func (s *System) Emit(n int) int {
+	created := 0
+	for ; created < n; created++ {
+		if !s.spawn(0, 0, geo.Vec{}) {
+			break
+		}
+	}
+	return created
+}
+
+// spawn creates a particle at time at within a frame of length dt and simulates it for
+// the rest of the frame with force applied. It returns false if the pool is full.
+func (s *System) spawn(at, dt time.Duration, force geo.Vec) bool {
+	if len(s.freeList) == 0 {
+		return false
+	}
+	p := <-s.freeList
+	p.inFreeList = false
+	p.Active = true
+	p.Life = s.InitLife
+	p.Pos = s.Pos
+	if dt > 0 {
+		p.Pos = s.lastPos.Plus(s.Pos.Minus(s.lastPos).Times(float64(at) / float64(dt)))
+	}
+	p.Pos.Add(s.InitPos())
+	p.Vel = s.InitVel()
+	p.Mass = s.InitMass()
+
+	if rest := dt - at; rest > 0 {
+		p.ApplyForce(force)
+		p.Update(rest)
+		p.Life -= rest
+		if p.Life <= 0 {
+			p.Active = false
+		}
 	}
+	return true
 }
 
 // ApplyForce applies a force to each particle. Forces are cleared after each call to Update.
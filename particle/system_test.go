@@ -0,0 +1,192 @@
+package particle
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const e = 1e-10
+
+func TestSystemDeterministic(t *testing.T) {
+	run := func(seed int64) []geo.Vec {
+		rng := geo.NewRngSeed(seed)
+		s := NewSystem(100)
+		s.Rate = 60
+		s.InitLife = time.Second
+		s.InitPos = rng.RandVecCircle(0, 10)
+		s.InitVel = rng.RandVecArc(10, 100, 0, math.Pi)
+		s.InitMass = rng.RandNum(0.5, 2)
+		for i := 0; i < 120; i++ {
+			s.ApplyForce(geo.Vec{Y: 10})
+			s.Update(time.Second / 60)
+		}
+		pos := []geo.Vec{}
+		s.ForEachParticle(func(p *SystemParticle) {
+			pos = append(pos, p.Pos)
+		})
+		return pos
+	}
+
+	p1, p2 := run(7), run(7)
+	if len(p1) == 0 || len(p1) != len(p2) {
+		t.Fatalf("got %d and %d particles", len(p1), len(p2))
+	}
+	for i := range p1 {
+		if p1[i] != p2[i] {
+			t.Errorf("particle %d: %#v != %#v", i, p1[i], p2[i])
+		}
+	}
+}
+
+func newTestSystem(size int) *System {
+	s := NewSystem(size)
+	s.InitLife = time.Hour
+	s.InitPos = geo.StaticVec(geo.Vec{})
+	s.InitVel = geo.StaticVec(geo.Vec{X: 60})
+	s.InitMass = geo.ConstNum(1)
+	return s
+}
+
+func TestSystemRate(t *testing.T) {
+	cases := []struct {
+		rate   float64
+		frames int
+		dt     time.Duration
+		want   int
+	}{
+		// time.Second / 60 is a little under a 60th of a second.
+		{10, 61, time.Second / 60, 10},
+		{10, 145, time.Second / 144, 10},
+		{10, 1000, time.Millisecond, 10},
+		{2.5, 7, time.Second, 17},
+		{100, 10, time.Second / 60, 16},
+		{0, 60, time.Second / 60, 0},
+	}
+
+	for i, c := range cases {
+		s := newTestSystem(1000)
+		s.Rate = c.rate
+		for f := 0; f < c.frames; f++ {
+			s.Update(c.dt)
+		}
+		if got := len(s.Particles()); got != c.want {
+			t.Errorf("case %d: got %d particles, want %d", i, got, c.want)
+		}
+	}
+
+	// Particles due while full are dropped.
+	s := newTestSystem(5)
+	s.Rate = 10
+	s.Update(time.Second)
+	s.ForEachParticle(func(p *SystemParticle) {
+		p.Life = 0
+	})
+	s.Update(time.Second / 10)
+	if got := len(s.Particles()); got != 1 {
+		t.Errorf("got %d particles after being full, want 1", got)
+	}
+}
+
+func TestSystemSubFrame(t *testing.T) {
+	s := newTestSystem(10)
+	s.Rate = 4
+	s.InitLife = 900 * time.Millisecond
+	s.Pos = geo.Vec{Y: 10}
+	s.Update(0)
+	s.Pos = geo.Vec{Y: 50}
+	s.Update(time.Second)
+
+	// Emitted at 0.25, 0.5, 0.75 and 1 seconds, having moved since then.
+	want := []geo.Vec{{X: 45, Y: 20}, {X: 30, Y: 30}, {X: 15, Y: 40}, {X: 0, Y: 50}}
+	got := s.Particles()
+	if len(got) != len(want) {
+		t.Fatalf("got %d particles, want %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if !p.Pos.Equals(want[i], e) {
+			t.Errorf("particle %d: got %#v, want %#v", i, p.Pos, want[i])
+		}
+	}
+	if want := 150 * time.Millisecond; got[0].Life != want {
+		t.Errorf("got life %v, want %v", got[0].Life, want)
+	}
+}
+
+func TestSystemEmit(t *testing.T) {
+	s := newTestSystem(10)
+	s.Pos = geo.Vec{X: 3}
+	if got := s.Emit(4); got != 4 {
+		t.Errorf("got %d, want 4", got)
+	}
+	if got := s.Emit(10); got != 6 {
+		t.Errorf("got %d, want 6", got)
+	}
+	for i, p := range s.Particles() {
+		if p.Pos != s.Pos || p.Life != s.InitLife {
+			t.Errorf("particle %d: got %#v with %v left", i, p.Pos, p.Life)
+		}
+	}
+}
+
+func TestSystemBursts(t *testing.T) {
+	s := newTestSystem(100)
+	s.Bursts = []Burst{
+		{At: 0, Count: 3},
+		{At: time.Second / 2, Count: 2, Interval: time.Second},
+	}
+	counts := []int{}
+	for i := 0; i < 6; i++ {
+		s.Update(time.Second / 2)
+		counts = append(counts, len(s.Particles()))
+	}
+	want := []int{3, 5, 5, 7, 7, 9}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Fatalf("got %v, want %v", counts, want)
+		}
+	}
+
+	// Bursts that land mid-frame move for the rest of it.
+	s = newTestSystem(100)
+	s.Bursts = []Burst{{At: time.Second / 4, Count: 1}}
+	s.Update(time.Second)
+	if got, want := s.Particles()[0].Pos, (geo.Vec{X: 45}); !got.Equals(want, e) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	run := func(seed int64) int {
+		s := newTestSystem(1000)
+		s.Rng = geo.NewRngSeed(seed)
+		s.Bursts = []Burst{{Count: 1, Interval: time.Second / 10, Probabilistic: true, Chance: 0.25}}
+		for i := 0; i < 100; i++ {
+			s.Update(time.Second)
+		}
+		return len(s.Particles())
+	}
+	// A chance of 0 or less never happens and one of 1 or more always does. Chance is
+	// ignored unless the Burst is Probabilistic.
+	s = newTestSystem(100)
+	s.Bursts = []Burst{
+		{Count: 1, Interval: time.Second, Probabilistic: true},
+		{Count: 1, Interval: time.Second, Probabilistic: true, Chance: -0.5},
+		{Count: 2, Interval: time.Second, Probabilistic: true, Chance: 1.5},
+		{Count: 4, Interval: time.Second, Chance: 0},
+	}
+	for i := 0; i < 5; i++ {
+		s.Update(time.Second)
+	}
+	if got := len(s.Particles()); got != 30 {
+		t.Errorf("got %d particles, want 30", got)
+	}
+
+	got := run(3)
+	if got != run(3) {
+		t.Errorf("same seed emitted differently")
+	}
+	if got < 200 || got > 300 {
+		t.Errorf("got %d of 1000 with a chance of 0.25", got)
+	}
+}
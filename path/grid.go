@@ -0,0 +1,169 @@
+package path
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Diagonal controls when a Grid allows diagonal moves.
+type Diagonal int
+
+const (
+	// DiagonalNever only allows horizontal and vertical moves.
+	DiagonalNever Diagonal = iota
+	// DiagonalNoCorners allows diagonal moves only if both of the tiles beside the move are
+	// passable, so paths never clip the corner of a wall.
+	DiagonalNoCorners
+	// DiagonalOneCorner allows diagonal moves if at least one of the tiles beside the move
+	// is passable.
+	DiagonalOneCorner
+	// DiagonalAlways allows diagonal moves even between two walls.
+	DiagonalAlways
+)
+
+// Heuristic estimates the cost of moving between two tiles.
+type Heuristic func(a, b geo.Point) float64
+
+// Manhattan is the Heuristic for grids without diagonal moves.
+func Manhattan(a, b geo.Point) float64 {
+	return float64(a.ManhattanDist(b))
+}
+
+// Octile is the Heuristic for grids where diagonal moves cost √2.
+func Octile(a, b geo.Point) float64 {
+	dx, dy := math.Abs(float64(a.X-b.X)), math.Abs(float64(a.Y-b.Y))
+	return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+}
+
+// Euclidean is the straight line distance. It never overestimates, but is slower than the
+// others because it underestimates grid paths the most.
+func Euclidean(a, b geo.Point) float64 {
+	return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y))
+}
+
+// Chebyshev is the Heuristic for grids where diagonal moves cost 1 like the others.
+func Chebyshev(a, b geo.Point) float64 {
+	return float64(a.ChebyshevDist(b))
+}
+
+// Grid is a Graph of tiles. Horizontal and vertical steps cost 1 and diagonal steps cost
+// √2, each multiplied by the Cost of the tile being entered.
+type Grid struct {
+	// Passable returns true if the tile at p can be walked on. It must return false for
+	// tiles outside of the map.
+	Passable func(p geo.Point) bool
+	// Cost returns the cost of entering the tile at p. If it is nil every tile costs 1.
+	// Costs less than 1 will make the Heuristic overestimate.
+	Cost func(p geo.Point) float64
+	// Diagonal is when diagonal moves are allowed.
+	Diagonal Diagonal
+	// Heuristic is used by AStar. If it is nil then Manhattan is used if Diagonal is
+	// DiagonalNever, otherwise Octile.
+	Heuristic Heuristic
+	// TileW and TileH are the size of a tile in world coordinates, which is used by the
+	// functions that take or return Vecs.
+	TileW, TileH float64
+}
+
+var _ Graph[geo.Point] = (*Grid)(nil)
+
+// Neighbors calls f with each passable tile next to p along with the cost of moving to it.
+func (g *Grid) Neighbors(p geo.Point, f func(next geo.Point, cost float64)) {
+	var open [4]bool
+	for i, d := range geo.Neighbors4 {
+		next := p.Plus(d)
+		if open[i] = g.Passable(next); open[i] {
+			f(next, g.cost(next))
+		}
+	}
+	if g.Diagonal == DiagonalNever {
+		return
+	}
+	// Each diagonal is between two of the Neighbors4, which start above and go clockwise.
+	for i := range open {
+		j := (i + 1) % 4
+		next := p.Plus(geo.Neighbors4[i]).Plus(geo.Neighbors4[j])
+		switch {
+		case g.Diagonal == DiagonalNoCorners && !(open[i] && open[j]):
+			continue
+		case g.Diagonal == DiagonalOneCorner && !(open[i] || open[j]):
+			continue
+		}
+		if g.Passable(next) {
+			f(next, math.Sqrt2*g.cost(next))
+		}
+	}
+}
+
+// Estimate returns the Heuristic's estimate from a to b.
+func (g *Grid) Estimate(a, b geo.Point) float64 {
+	if g.Heuristic != nil {
+		return g.Heuristic(a, b)
+	}
+	if g.Diagonal == DiagonalNever {
+		return Manhattan(a, b)
+	}
+	return Octile(a, b)
+}
+
+func (g *Grid) cost(p geo.Point) float64 {
+	if g.Cost == nil {
+		return 1
+	}
+	return g.Cost(p)
+}
+
+// AStar returns the cheapest path of tiles from start to goal, including both.
+func (g *Grid) AStar(start, goal geo.Point) ([]geo.Point, bool) {
+	return AStar[geo.Point](g, start, goal)
+}
+
+// LineOfSight returns true if every tile touched by the line between the centers of a and
+// b is passable.
+func (g *Grid) LineOfSight(a, b geo.Point) bool {
+	for _, p := range geo.SupercoverLine(a, b) {
+		if !g.Passable(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Smooth removes tiles from path that can be skipped by walking in a straight line, as
+// decided by LineOfSight. The result is shorter and looks more natural, but it ignores
+// Cost so it may walk through expensive tiles.
+func (g *Grid) Smooth(path []geo.Point) []geo.Point {
+	return Smooth(path, g.LineOfSight)
+}
+
+// Waypoints returns the centers of the tiles in path in world coordinates.
+func (g *Grid) Waypoints(path []geo.Point) []geo.Vec {
+	vecs := make([]geo.Vec, len(path))
+	for i, p := range path {
+		vecs[i] = p.TileCenter(g.TileW, g.TileH)
+	}
+	return vecs
+}
+
+// FindPath finds a path between two positions in world coordinates using AStar, smooths
+// it, and returns the waypoints. The first waypoint is from and the last is to, the rest
+// are tile centers. ok is false if either position is in an impassable tile or there is
+// no path.
+func (g *Grid) FindPath(from, to geo.Vec) (waypoints []geo.Vec, ok bool) {
+	start, goal := geo.TileAt(from, g.TileW, g.TileH), geo.TileAt(to, g.TileW, g.TileH)
+	if !g.Passable(start) || !g.Passable(goal) {
+		return nil, false
+	}
+	path, ok := g.AStar(start, goal)
+	if !ok {
+		return nil, false
+	}
+	waypoints = g.Waypoints(g.Smooth(path))
+	waypoints[0] = from
+	if len(waypoints) == 1 {
+		return append(waypoints, to), true
+	}
+	waypoints[len(waypoints)-1] = to
+	return waypoints, true
+}
@@ -0,0 +1,201 @@
+package path
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// makeGrid returns a Grid from rows of text where '#' is a wall and '~' costs 5.
+func makeGrid(rows []string, diagonal Diagonal) *Grid {
+	return &Grid{
+		Passable: func(p geo.Point) bool {
+			return p.Y >= 0 && p.Y < len(rows) && p.X >= 0 && p.X < len(rows[p.Y]) && rows[p.Y][p.X] != '#'
+		},
+		Cost: func(p geo.Point) float64 {
+			if rows[p.Y][p.X] == '~' {
+				return 5
+			}
+			return 1
+		},
+		Diagonal: diagonal,
+		TileW:    10,
+		TileH:    10,
+	}
+}
+
+// pathCost returns the cost of walking path on g, or -1 if it makes an invalid move.
+func pathCost(g *Grid, path []geo.Point) float64 {
+	cost := 0.0
+	for i := 1; i < len(path); i++ {
+		found := false
+		g.Neighbors(path[i-1], func(next geo.Point, c float64) {
+			if next == path[i] {
+				cost += c
+				found = true
+			}
+		})
+		if !found {
+			return -1
+		}
+	}
+	return cost
+}
+
+func TestGridAStar(t *testing.T) {
+	rows := []string{
+		".....",
+		".###.",
+		".#...",
+		".#.#.",
+		"...#.",
+	}
+	mud := []string{
+		"...",
+		".~.",
+		"...",
+	}
+	start, goal := geo.Point{X: 0, Y: 0}, geo.Point{X: 2, Y: 3}
+	cases := []struct {
+		grid       *Grid
+		start, end geo.Point
+		ok         bool
+		cost       float64
+	}{
+		{makeGrid(rows, DiagonalNever), start, goal, true, 7},
+		{makeGrid(rows, DiagonalNoCorners), start, goal, true, 7},
+		{makeGrid(rows, DiagonalOneCorner), start, goal, true, 3 + 2*math.Sqrt2},
+		{makeGrid(rows, DiagonalAlways), start, goal, true, 3 + 2*math.Sqrt2},
+		{makeGrid(rows, DiagonalNever), start, start, true, 0},
+		{makeGrid(rows, DiagonalNever), start, geo.Point{X: 3, Y: 1}, false, 0},
+		{makeGrid(rows, DiagonalNever), start, geo.Point{X: 10, Y: 10}, false, 0},
+		{makeGrid(mud, DiagonalNever), geo.Point{X: 1, Y: 0}, geo.Point{X: 1, Y: 2}, true, 4},
+		{makeGrid(mud, DiagonalAlways), geo.Point{X: 0, Y: 0}, geo.Point{X: 2, Y: 2}, true, 2 + math.Sqrt2},
+	}
+
+	for i, c := range cases {
+		path, ok := c.grid.AStar(c.start, c.end)
+		if ok != c.ok {
+			t.Errorf("case %d: got ok %v, want %v", i, ok, c.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if path[0] != c.start || path[len(path)-1] != c.end {
+			t.Errorf("case %d: path %v doesn't go from %v to %v", i, path, c.start, c.end)
+		}
+		if cost := pathCost(c.grid, path); math.Abs(cost-c.cost) > 1e-9 {
+			t.Errorf("case %d: got cost %v, want %v for %v", i, cost, c.cost, path)
+		}
+	}
+}
+
+func TestJPS(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		rows := make([]string, 30)
+		for y := range rows {
+			row := make([]byte, 40)
+			for x := range row {
+				row[x] = '.'
+				if r.Float64() < 0.3 {
+					row[x] = '#'
+				}
+			}
+			rows[y] = string(row)
+		}
+		g := makeGrid(rows, DiagonalNoCorners)
+		start := geo.Point{X: r.Intn(40), Y: r.Intn(30)}
+		goal := geo.Point{X: r.Intn(40), Y: r.Intn(30)}
+		if !g.Passable(start) || !g.Passable(goal) {
+			continue
+		}
+
+		want, wantOK := g.AStar(start, goal)
+		got, ok := g.JPS(start, goal)
+		if ok != wantOK {
+			t.Errorf("trial %d: got ok %v, want %v", trial, ok, wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		// Fill in the jumps to check that each move is valid.
+		full := []geo.Point{got[0]}
+		for i := 1; i < len(got); i++ {
+			d := got[i].Minus(got[i-1])
+			if d.X != 0 && d.Y != 0 && absInt(d.X) != absInt(d.Y) {
+				t.Fatalf("trial %d: jump from %v to %v isn't straight", trial, got[i-1], got[i])
+			}
+			step := d.Sign()
+			for p := got[i-1].Plus(step); p != got[i]; p = p.Plus(step) {
+				full = append(full, p)
+			}
+			full = append(full, got[i])
+		}
+		cost, wantCost := pathCost(g, full), pathCost(g, want)
+		if math.Abs(cost-wantCost) > 1e-9 {
+			t.Errorf("trial %d: got cost %v, want %v", trial, cost, wantCost)
+		}
+	}
+}
+
+func TestGridSmooth(t *testing.T) {
+	rows := []string{
+		"......",
+		"......",
+		"..##..",
+		"......",
+	}
+	g := makeGrid(rows, DiagonalNever)
+	path, _ := g.AStar(geo.Point{X: 0, Y: 3}, geo.Point{X: 5, Y: 0})
+	smooth := g.Smooth(path)
+	if len(smooth) >= len(path) || len(smooth) < 3 {
+		t.Errorf("got %d waypoints from %d, %v", len(smooth), len(path), smooth)
+	}
+	for i := 1; i < len(smooth); i++ {
+		if !g.LineOfSight(smooth[i-1], smooth[i]) {
+			t.Errorf("no line of sight from %v to %v", smooth[i-1], smooth[i])
+		}
+	}
+	if g.LineOfSight(geo.Point{X: 1, Y: 2}, geo.Point{X: 4, Y: 2}) {
+		t.Errorf("line of sight through a wall")
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	rows := []string{
+		"....",
+		"###.",
+		"....",
+	}
+	g := makeGrid(rows, DiagonalNoCorners)
+	from, to := geo.Vec{X: 2, Y: 3}, geo.Vec{X: 4, Y: 27}
+	got, ok := g.FindPath(from, to)
+	want := []geo.Vec{from, {X: 35, Y: 5}, {X: 35, Y: 25}, to}
+	if !ok || len(got) != len(want) {
+		t.Fatalf("got %v %v, want %v", got, ok, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("waypoint %d: got %#v, want %#v", i, got[i], want[i])
+		}
+	}
+
+	if got, ok := g.FindPath(from, geo.Vec{X: 8, Y: 3}); !ok || len(got) != 2 {
+		t.Errorf("same tile: got %v %v", got, ok)
+	}
+	if _, ok := g.FindPath(from, geo.Vec{X: 5, Y: 15}); ok {
+		t.Errorf("path to a wall")
+	}
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,127 @@
+package path
+
+import "github.com/Bredgren/gogame/geo"
+
+// JPS returns the shortest path from start to goal using Jump Point Search. It is usually
+// much faster than AStar on large open maps, but it ignores Cost, Diagonal and Heuristic
+// and always moves as if Diagonal were DiagonalNoCorners with every tile costing 1. The
+// path only includes the tiles where it changes direction, so consecutive tiles may be
+// far apart but are always in a straight or diagonal line.
+func (g *Grid) JPS(start, goal geo.Point) ([]geo.Point, bool) {
+	return search(start, goal, Octile, func(p geo.Point, parent *geo.Point, f func(geo.Point, float64)) {
+		for _, d := range g.jumpDirections(p, parent) {
+			if jp, ok := g.jump(p.Plus(d), d, goal); ok {
+				f(jp, Octile(p, jp))
+			}
+		}
+	})
+}
+
+// jumpDirections returns the directions worth searching from p when arriving from parent.
+func (g *Grid) jumpDirections(p geo.Point, parent *geo.Point) []geo.Point {
+	dirs := make([]geo.Point, 0, 8)
+	open := func(dx, dy int) bool {
+		return g.Passable(geo.Point{X: p.X + dx, Y: p.Y + dy})
+	}
+	add := func(dx, dy int) {
+		dirs = append(dirs, geo.Point{X: dx, Y: dy})
+	}
+
+	if parent == nil {
+		for _, d := range geo.Neighbors8 {
+			if open(d.X, d.Y) && open(d.X, 0) && open(0, d.Y) {
+				add(d.X, d.Y)
+			}
+		}
+		return dirs
+	}
+
+	dir := p.Minus(*parent).Sign()
+	dx, dy := dir.X, dir.Y
+	switch {
+	case dx != 0 && dy != 0:
+		v, h := open(0, dy), open(dx, 0)
+		if v {
+			add(0, dy)
+		}
+		if h {
+			add(dx, 0)
+		}
+		if v && h {
+			add(dx, dy)
+		}
+	case dx != 0:
+		next, up, down := open(dx, 0), open(0, -1), open(0, 1)
+		if next {
+			add(dx, 0)
+			if up {
+				add(dx, -1)
+			}
+			if down {
+				add(dx, 1)
+			}
+		}
+		if up {
+			add(0, -1)
+		}
+		if down {
+			add(0, 1)
+		}
+	default:
+		next, left, right := open(0, dy), open(-1, 0), open(1, 0)
+		if next {
+			add(0, dy)
+			if left {
+				add(-1, dy)
+			}
+			if right {
+				add(1, dy)
+			}
+		}
+		if left {
+			add(-1, 0)
+		}
+		if right {
+			add(1, 0)
+		}
+	}
+	return dirs
+}
+
+// jump moves from p in direction d until it finds a tile worth stopping at, which is the
+// goal or a tile where the path might need to turn.
+func (g *Grid) jump(p, d, goal geo.Point) (geo.Point, bool) {
+	open := func(dx, dy int) bool {
+		return g.Passable(geo.Point{X: p.X + dx, Y: p.Y + dy})
+	}
+	for {
+		if !g.Passable(p) {
+			return p, false
+		}
+		if p == goal {
+			return p, true
+		}
+		switch {
+		case d.X != 0 && d.Y != 0:
+			if _, ok := g.jump(geo.Point{X: p.X + d.X, Y: p.Y}, geo.Point{X: d.X}, goal); ok {
+				return p, true
+			}
+			if _, ok := g.jump(geo.Point{X: p.X, Y: p.Y + d.Y}, geo.Point{Y: d.Y}, goal); ok {
+				return p, true
+			}
+			// Going any further diagonally would cut a corner.
+			if !open(d.X, 0) || !open(0, d.Y) {
+				return p, false
+			}
+		case d.X != 0:
+			if open(0, -1) && !open(-d.X, -1) || open(0, 1) && !open(-d.X, 1) {
+				return p, true
+			}
+		default:
+			if open(-1, 0) && !open(-1, -d.Y) || open(1, 0) && !open(1, -d.Y) {
+				return p, true
+			}
+		}
+		p = p.Plus(d)
+	}
+}
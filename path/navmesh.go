@@ -0,0 +1,215 @@
+package path
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// navSides is the number of sides used to approximate the agent's circle when inflating
+// obstacles.
+const navSides = 16
+
+// NavMesh is a Graph of triangles covering the walkable area of a level. It gives smoother
+// and cheaper paths than a Grid for levels made of arbitrary shapes. The nodes are indexes
+// into Triangles and the costs are the distances between their centroids.
+type NavMesh struct {
+	// Triangles are the walkable triangles, each wound clockwise. They can be drawn to
+	// debug the mesh.
+	Triangles []geo.Polygon
+	centroids []geo.Vec
+	portals   [][]portal
+}
+
+// portal is an edge shared between two triangles, with left and right as seen when
+// walking through it to the triangle at index to.
+type portal struct {
+	to          int
+	left, right geo.Vec
+}
+
+var _ Graph[int] = (*NavMesh)(nil)
+
+// NewNavMesh returns a NavMesh covering the walkable polygons minus the obstacles. The
+// polygons are regions as used by geo.PolygonDifference, so a polygon inside another is a
+// hole. If radius is more than 0 then everything is shrunk away from the edges by that
+// much, so that a circular agent with that radius can follow any path without overlapping
+// an obstacle. Corners are approximated, erring on the side of too much space.
+func NewNavMesh(walkable, obstacles []geo.Polygon, radius float64) *NavMesh {
+	if radius > 0 {
+		obstacles = inflate(append(append([]geo.Polygon{}, walkable...), obstacles...), obstacles, radius)
+	}
+	region := walkable
+	if len(obstacles) > 0 {
+		region = geo.PolygonDifference(walkable, obstacles)
+	}
+
+	m := &NavMesh{}
+	for _, g := range geo.GroupHoles(region) {
+		m.Triangles = append(m.Triangles, geo.Triangulate(g[0], g[1:]...)...)
+	}
+	m.centroids = make([]geo.Vec, len(m.Triangles))
+	m.portals = make([][]portal, len(m.Triangles))
+	type edge struct{ a, b geo.Vec }
+	edges := make(map[edge]int, 3*len(m.Triangles))
+	for i, t := range m.Triangles {
+		m.centroids[i] = t.Centroid()
+		for j := range t {
+			a, b := t[j], t[(j+1)%len(t)]
+			// Neighboring triangles are wound the same way so they share the edge in
+			// reverse.
+			if other, ok := edges[edge{b, a}]; ok {
+				m.portals[i] = append(m.portals[i], portal{to: other, left: a, right: b})
+				m.portals[other] = append(m.portals[other], portal{to: i, left: b, right: a})
+				continue
+			}
+			edges[edge{a, b}] = i
+		}
+	}
+	return m
+}
+
+// inflate returns obstacles grown by radius along with a border of that width around the
+// edges of every polygon in edges. Each edge becomes the convex hull of a circle at either
+// end, and circles share vertices so that neighboring edges join cleanly.
+func inflate(edges, obstacles []geo.Polygon, radius float64) []geo.Polygon {
+	// Put the edges of the circle polygon at radius, instead of its vertices.
+	r := radius / math.Cos(math.Pi/navSides)
+	grown := obstacles
+	for _, p := range edges {
+		for i := range p {
+			a, b := p[i], p[(i+1)%len(p)]
+			ca := geo.CirclePolygon(geo.Circle{X: a.X, Y: a.Y, R: r}, navSides)
+			cb := geo.CirclePolygon(geo.Circle{X: b.X, Y: b.Y, R: r}, navSides)
+			grown = geo.PolygonUnion(grown, []geo.Polygon{geo.ConvexHull(append(ca, cb...))})
+		}
+	}
+	return grown
+}
+
+// Neighbors calls f with each triangle that shares an edge with triangle i along with the
+// distance between their centroids.
+func (m *NavMesh) Neighbors(i int, f func(next int, cost float64)) {
+	for _, p := range m.portals[i] {
+		f(p.to, m.centroids[i].Dist(m.centroids[p.to]))
+	}
+}
+
+// Estimate returns the distance between the centroids of triangles a and b.
+func (m *NavMesh) Estimate(a, b int) float64 {
+	return m.centroids[a].Dist(m.centroids[b])
+}
+
+// Contains returns true if v is on the mesh.
+func (m *NavMesh) Contains(v geo.Vec) bool {
+	_, near := m.locate(v)
+	return near == v
+}
+
+// Nearest returns the closest point to v that is on the mesh, which is v itself if it's
+// already on the mesh. If the mesh is empty it returns v.
+func (m *NavMesh) Nearest(v geo.Vec) geo.Vec {
+	_, near := m.locate(v)
+	return near
+}
+
+// locate returns the index of the triangle closest to v and the closest point in it, or
+// -1 if the mesh is empty.
+func (m *NavMesh) locate(v geo.Vec) (int, geo.Vec) {
+	best, bestDist, bestPoint := -1, math.Inf(1), v
+	for i, t := range m.Triangles {
+		p := t.ClosestPoint(v)
+		if d := p.Dist2(v); d < bestDist {
+			best, bestDist, bestPoint = i, d, p
+			if d == 0 {
+				break
+			}
+		}
+	}
+	return best, bestPoint
+}
+
+// FindPath returns the shortest waypoints from one position to another along the mesh,
+// found with AStar over the triangles and then pulled tight with the funnel algorithm.
+// Positions off the mesh are first moved to the Nearest point on it, so the first and last
+// waypoints may not be from and to. ok is false if the mesh is empty or the positions
+// aren't connected.
+func (m *NavMesh) FindPath(from, to geo.Vec) (waypoints []geo.Vec, ok bool) {
+	start, from := m.locate(from)
+	goal, to := m.locate(to)
+	if start < 0 {
+		return nil, false
+	}
+	tris, ok := AStar[int](m, start, goal)
+	if !ok {
+		return nil, false
+	}
+
+	// Each portal is stored as a pair of points with the start and end as portals of zero
+	// width.
+	portals := make([]geo.Vec, 0, 2*len(tris)+2)
+	portals = append(portals, from, from)
+	for i := 1; i < len(tris); i++ {
+		for _, p := range m.portals[tris[i-1]] {
+			if p.to == tris[i] {
+				portals = append(portals, p.left, p.right)
+				break
+			}
+		}
+	}
+	portals = append(portals, to, to)
+	return funnel(portals), true
+}
+
+// funnel returns the shortest path through portals, which are pairs of left and right
+// points, using the "simple stupid funnel algorithm". The funnel starts at the apex and
+// is narrowed by each portal in turn until one side crosses the other, at which point the
+// crossed corner becomes a waypoint and the new apex.
+func funnel(portals []geo.Vec) []geo.Vec {
+	apex, left, right := portals[0], portals[0], portals[1]
+	apexIndex, leftIndex, rightIndex := 0, 0, 0
+	path := []geo.Vec{apex}
+
+	for i := 1; i < len(portals)/2; i++ {
+		l, r := portals[2*i], portals[2*i+1]
+
+		if side(apex, right, r) >= 0 {
+			if apex == right || side(apex, left, r) < 0 {
+				right, rightIndex = r, i
+			} else {
+				// The right side crossed the left, so go around the left corner.
+				apex, apexIndex = left, leftIndex
+				path = append(path, apex)
+				left, right = apex, apex
+				leftIndex, rightIndex = apexIndex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+
+		if side(apex, left, l) <= 0 {
+			if apex == left || side(apex, right, l) > 0 {
+				left, leftIndex = l, i
+			} else {
+				apex, apexIndex = right, rightIndex
+				path = append(path, apex)
+				left, right = apex, apex
+				leftIndex, rightIndex = apexIndex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+	}
+
+	if end := portals[len(portals)-1]; path[len(path)-1] != end || len(path) == 1 {
+		path = append(path, end)
+	}
+	return path
+}
+
+// side returns a positive number if c is to the left of the line from a through b, as
+// seen on screen, a negative number if it's to the right, and 0 if it's on the line.
+func side(a, b, c geo.Vec) float64 {
+	u, v := b.Minus(a), c.Minus(a)
+	return u.Y*v.X - u.X*v.Y
+}
@@ -0,0 +1,128 @@
+package path
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const e = 1e-10
+
+func pathLen(path []geo.Vec) float64 {
+	l := 0.0
+	for i := 1; i < len(path); i++ {
+		l += path[i].Dist(path[i-1])
+	}
+	return l
+}
+
+func TestNavMeshFindPath(t *testing.T) {
+	room := []geo.Polygon{geo.RectPolygon(geo.Rect{W: 100, H: 100})}
+	pillar := geo.RectPolygon(geo.Rect{X: 40, Y: 30, W: 20, H: 40})
+	apart := []geo.Polygon{
+		geo.RectPolygon(geo.Rect{W: 10, H: 10}),
+		geo.RectPolygon(geo.Rect{X: 20, W: 10, H: 10}),
+	}
+	cases := []struct {
+		mesh     *NavMesh
+		from, to geo.Vec
+		ok       bool
+		want     []geo.Vec
+	}{
+		{NewNavMesh(room, nil, 0), geo.Vec{X: 10, Y: 20}, geo.Vec{X: 90, Y: 70}, true,
+			[]geo.Vec{{X: 10, Y: 20}, {X: 90, Y: 70}}},
+		{NewNavMesh(room, []geo.Polygon{pillar}, 0), geo.Vec{X: 10, Y: 50}, geo.Vec{X: 90, Y: 40}, true,
+			[]geo.Vec{{X: 10, Y: 50}, {X: 40, Y: 30}, {X: 60, Y: 30}, {X: 90, Y: 40}}},
+		{NewNavMesh(room, []geo.Polygon{pillar}, 0), geo.Vec{X: 50, Y: 10}, geo.Vec{X: 50, Y: 90}, true, nil},
+		{NewNavMesh(room, []geo.Polygon{pillar}, 0), geo.Vec{X: 50, Y: 35}, geo.Vec{X: 45, Y: 10}, true,
+			[]geo.Vec{{X: 50, Y: 30}, {X: 45, Y: 10}}},
+		{NewNavMesh(room, []geo.Polygon{pillar}, 0), geo.Vec{X: 5, Y: 5}, geo.Vec{X: 5, Y: 5}, true,
+			[]geo.Vec{{X: 5, Y: 5}, {X: 5, Y: 5}}},
+		{NewNavMesh(apart, nil, 0), geo.Vec{X: 5, Y: 5}, geo.Vec{X: 25, Y: 5}, false, nil},
+		{NewNavMesh(nil, nil, 0), geo.Vec{X: 5, Y: 5}, geo.Vec{X: 25, Y: 5}, false, nil},
+	}
+
+	for i, c := range cases {
+		got, ok := c.mesh.FindPath(c.from, c.to)
+		if ok != c.ok {
+			t.Errorf("case %d: got ok %v, want %v", i, ok, c.ok)
+			continue
+		}
+		if c.want == nil {
+			continue
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("case %d: got %v, want %v", i, got, c.want)
+			continue
+		}
+		for j := range got {
+			if !got[j].Equals(c.want[j], e) {
+				t.Errorf("case %d: waypoint %d: got %#v, want %#v", i, j, got[j], c.want[j])
+			}
+		}
+	}
+
+	// Either way around the pillar is as short.
+	m := NewNavMesh(room, []geo.Polygon{pillar}, 0)
+	got, _ := m.FindPath(geo.Vec{X: 50, Y: 10}, geo.Vec{X: 50, Y: 90})
+	if want := 40 + 2*math.Hypot(10, 20); len(got) != 4 || math.Abs(pathLen(got)-want) > e {
+		t.Errorf("got %v with length %v, want length %v", got, pathLen(got), want)
+	}
+}
+
+func TestNavMeshRadius(t *testing.T) {
+	const radius = 5
+	room := []geo.Polygon{geo.RectPolygon(geo.Rect{W: 100, H: 100})}
+	pillar := geo.RectPolygon(geo.Rect{X: 40, Y: 30, W: 20, H: 40})
+	m := NewNavMesh(room, []geo.Polygon{pillar}, radius)
+
+	if m.Contains(geo.Vec{X: 2, Y: 50}) || m.Contains(geo.Vec{X: 38, Y: 50}) {
+		t.Errorf("mesh contains points within radius of a wall")
+	}
+	if !m.Contains(geo.Vec{X: 20, Y: 50}) {
+		t.Errorf("mesh doesn't contain open point")
+	}
+	if got := m.Nearest(geo.Vec{X: -10, Y: 50}); got.X < radius || got.X > radius+0.2 || math.Abs(got.Y-50) > 1e-9 {
+		t.Errorf("nearest: got %#v", got)
+	}
+
+	from, to := geo.Vec{X: 10, Y: 50}, geo.Vec{X: 90, Y: 50}
+	got, ok := m.FindPath(from, to)
+	if !ok || got[0] != from || got[len(got)-1] != to {
+		t.Fatalf("got %v %v", got, ok)
+	}
+	for i := 1; i < len(got); i++ {
+		// Check points along each leg keep their distance from the pillar.
+		for s := 0.0; s <= 1; s += 0.01 {
+			v := got[i-1].Plus(got[i].Minus(got[i-1]).Times(s))
+			if d := pillar.Dist(v); d < radius-1e-9 {
+				t.Fatalf("%#v on %v is %v from the pillar", v, got, d)
+			}
+		}
+	}
+	if unsafe := 20 + 2*math.Hypot(30, 20); pathLen(got) <= unsafe {
+		t.Errorf("path %v is too short, %v", got, pathLen(got))
+	}
+}
+
+func TestFunnel(t *testing.T) {
+	// A corridor that dips down then comes back up.
+	portals := []geo.Vec{
+		{X: 0, Y: 0}, {X: 0, Y: 0},
+		{X: 10, Y: -5}, {X: 10, Y: 5},
+		{X: 20, Y: 12}, {X: 20, Y: 20},
+		{X: 30, Y: -5}, {X: 30, Y: 5},
+		{X: 40, Y: 0}, {X: 40, Y: 0},
+	}
+	want := []geo.Vec{{X: 0, Y: 0}, {X: 10, Y: 5}, {X: 20, Y: 12}, {X: 30, Y: 5}, {X: 40, Y: 0}}
+	got := funnel(portals)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("waypoint %d: got %#v, want %#v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Package path finds paths through graphs with A*. Grid covers the common case of a tile
+// map, including Jump Point Search, smoothing and conversion to world coordinates.
+// NavMesh covers levels made of arbitrary polygons, and anything else can be searched by
+// implementing Graph.
+//
+// A typical use for an enemy chasing the player around walls:
+//
+//	grid := &path.Grid{Passable: level.Walkable, Diagonal: path.DiagonalNoCorners, TileW: 32, TileH: 32}
+//	...
+//	if waypoints, ok := grid.FindPath(enemy.Pos, player.Pos); ok {
+//		enemy.Follow(waypoints)
+//	}
+package path
+
+import "container/heap"
+
+// Graph is anything that can be searched by AStar. N identifies a node, such as an index
+// into a list of waypoints.
+type Graph[N comparable] interface {
+	// Neighbors calls f with each node that can be reached from n in one step along with the
+	// cost of that step. Costs must not be negative.
+	Neighbors(n N, f func(next N, cost float64))
+	// Estimate guesses the cost of the cheapest path from a to b. If it never guesses more
+	// than the true cost then AStar finds the cheapest path, otherwise it may find a more
+	// expensive one, though usually faster.
+	Estimate(a, b N) float64
+}
+
+// AStar returns the cheapest path from start to goal in g, including both. If there is no
+// path then ok is false. If g is infinite and there is no path then AStar never returns.
+func AStar[N comparable](g Graph[N], start, goal N) (path []N, ok bool) {
+	return search(start, goal, g.Estimate, func(n N, parent *N, f func(N, float64)) {
+		g.Neighbors(n, f)
+	})
+}
+
+// Smooth returns path with any waypoints removed that can be skipped because the one
+// before can see the one after, according to visible. The first and last waypoints are
+// always kept.
+func Smooth[N any](path []N, visible func(a, b N) bool) []N {
+	if len(path) < 3 {
+		return append([]N{}, path...)
+	}
+	smooth := []N{path[0]}
+	for i := 0; i < len(path)-1; {
+		j := len(path) - 1
+		for j > i+1 && !visible(path[i], path[j]) {
+			j--
+		}
+		smooth = append(smooth, path[j])
+		i = j
+	}
+	return smooth
+}
+
+type node[N comparable] struct {
+	n      N
+	g, f   float64
+	parent *node[N]
+	index  int
+	closed bool
+}
+
+// search is A* where the neighbors of a node may depend on the node before it, which is
+// nil for start.
+func search[N comparable](start, goal N, estimate func(a, b N) float64,
+	neighbors func(n N, parent *N, f func(N, float64))) ([]N, bool) {
+	nodes := map[N]*node[N]{}
+	open := openList[N]{}
+	s := &node[N]{n: start, f: estimate(start, goal)}
+	nodes[start] = s
+	heap.Push(&open, s)
+
+	for open.Len() > 0 {
+		cur := heap.Pop(&open).(*node[N])
+		if cur.n == goal {
+			return cur.path(), true
+		}
+		cur.closed = true
+		var parent *N
+		if cur.parent != nil {
+			parent = &cur.parent.n
+		}
+		neighbors(cur.n, parent, func(next N, cost float64) {
+			g := cur.g + cost
+			nn, ok := nodes[next]
+			if !ok {
+				nn = &node[N]{n: next, index: -1}
+				nodes[next] = nn
+			} else if nn.closed || g >= nn.g {
+				return
+			}
+			nn.g, nn.f, nn.parent = g, g+estimate(next, goal), cur
+			if nn.index < 0 {
+				heap.Push(&open, nn)
+			} else {
+				heap.Fix(&open, nn.index)
+			}
+		})
+	}
+	return nil, false
+}
+
+func (n *node[N]) path() []N {
+	count := 0
+	for p := n; p != nil; p = p.parent {
+		count++
+	}
+	path := make([]N, count)
+	for p := n; p != nil; p = p.parent {
+		count--
+		path[count] = p.n
+	}
+	return path
+}
+
+// openList is a priority queue of nodes with the lowest f first.
+type openList[N comparable] []*node[N]
+
+func (o openList[N]) Len() int {
+	return len(o)
+}
+
+func (o openList[N]) Less(i, j int) bool {
+	if o[i].f == o[j].f {
+		// Prefer nodes closer to the goal.
+		return o[i].g > o[j].g
+	}
+	return o[i].f < o[j].f
+}
+
+func (o openList[N]) Swap(i, j int) {
+	o[i], o[j] = o[j], o[i]
+	o[i].index = i
+	o[j].index = j
+}
+
+func (o *openList[N]) Push(x interface{}) {
+	n := x.(*node[N])
+	n.index = len(*o)
+	*o = append(*o, n)
+}
+
+func (o *openList[N]) Pop() interface{} {
+	old := *o
+	n := old[len(old)-1]
+	n.index = -1
+	*o = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,70 @@
+package path
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// roads is a Graph of named places with one way roads between them.
+type roads struct {
+	pos   map[string]geo.Vec
+	edges map[string][]string
+}
+
+func (r roads) Neighbors(n string, f func(string, float64)) {
+	for _, next := range r.edges[n] {
+		f(next, r.pos[n].Dist(r.pos[next]))
+	}
+}
+
+func (r roads) Estimate(a, b string) float64 {
+	return r.pos[a].Dist(r.pos[b])
+}
+
+func TestAStarGraph(t *testing.T) {
+	g := roads{
+		pos: map[string]geo.Vec{
+			"a": {X: 0, Y: 0}, "b": {X: 10, Y: 0}, "c": {X: 5, Y: 1},
+			"d": {X: 5, Y: 10}, "e": {X: 20, Y: 0},
+		},
+		edges: map[string][]string{
+			"a": {"b", "c", "d"},
+			"c": {"b"},
+			"d": {"b", "e"},
+			"b": {"a"},
+		},
+	}
+	cases := []struct {
+		start, goal string
+		want        []string
+	}{
+		{"a", "b", []string{"a", "b"}},
+		{"a", "e", []string{"a", "d", "e"}},
+		{"c", "a", []string{"c", "b", "a"}},
+		{"b", "b", []string{"b"}},
+		{"e", "a", nil},
+	}
+
+	for i, c := range cases {
+		got, ok := AStar[string](g, c.start, c.goal)
+		if ok != (c.want != nil) || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("case %d: got %v %v, want %v", i, got, ok, c.want)
+		}
+	}
+}
+
+func TestSmooth(t *testing.T) {
+	path := []int{0, 1, 2, 3, 4, 5, 6}
+	// Each point can see the next two, and 4 can't be skipped.
+	visible := func(a, b int) bool {
+		return b-a <= 2 && !(a < 4 && b > 4)
+	}
+	if got, want := Smooth(path, visible), []int{0, 2, 4, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := Smooth(path[:2], visible); !reflect.DeepEqual(got, path[:2]) {
+		t.Errorf("got %v, want %v", got, path[:2])
+	}
+}
@@ -0,0 +1,259 @@
+package physics
+
+import (
+	"math"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+	"github.com/Bredgren/gogame/particle"
+)
+
+// BodyType decides how a Body moves.
+type BodyType int
+
+const (
+	// Dynamic bodies are moved by gravity, forces and collisions.
+	Dynamic BodyType = iota
+	// Static bodies never move and are only hit by Dynamic ones, like the ground and walls.
+	Static
+	// Kinematic bodies move with their velocities but nothing pushes them, like moving
+	// platforms. They only collide with Dynamic bodies.
+	Kinematic
+)
+
+// Shape is the outline of a Body, centered on the Body's position. It is one of *Circle,
+// *Rect or *Polygon.
+type Shape interface {
+	// Area returns the area of the shape.
+	Area() float64
+	// inertia returns the moment of inertia about the center for the given mass.
+	inertia(mass float64) float64
+}
+
+// Circle is a circular Shape.
+type Circle struct {
+	R float64
+}
+
+// Area returns the area of the Circle.
+func (c *Circle) Area() float64 {
+	return math.Pi * c.R * c.R
+}
+
+func (c *Circle) inertia(mass float64) float64 {
+	return mass * c.R * c.R / 2
+}
+
+// Rect is a rectangular Shape of size W by H.
+type Rect struct {
+	W, H float64
+}
+
+// Area returns the area of the Rect.
+func (r *Rect) Area() float64 {
+	return r.W * r.H
+}
+
+func (r *Rect) inertia(mass float64) float64 {
+	return mass * (r.W*r.W + r.H*r.H) / 12
+}
+
+// Polygon is a Shape with any outline, which must be convex. The vertices are relative to
+// the Body's position, which should be near the middle. Concave shapes can be made from
+// several bodies, using geo.ConvexDecompose to split them up.
+type Polygon struct {
+	Vertices geo.Polygon
+}
+
+// Area returns the area of the Polygon.
+func (p *Polygon) Area() float64 {
+	return p.Vertices.Area()
+}
+
+func (p *Polygon) inertia(mass float64) float64 {
+	num, den := 0.0, 0.0
+	for i, a := range p.Vertices {
+		b := p.Vertices[(i+1)%len(p.Vertices)]
+		cross := math.Abs(a.X*b.Y - a.Y*b.X)
+		num += cross * (a.Dot(a) + a.Dot(b) + b.Dot(b))
+		den += cross
+	}
+	if den == 0 {
+		return 0
+	}
+	return mass * num / (6 * den)
+}
+
+// Body is a Particle with a Shape that can rotate and collide with other bodies. Use
+// NewBody to create one and World.Add to put it in a World.
+//
+// The embedded Particle's Update is used by the World and shouldn't be called directly.
+// ApplyForce pushes the Body's center without turning it.
+type Body struct {
+	particle.Particle
+	// Type decides how the Body moves.
+	Type BodyType
+	// Shape is the Body's outline.
+	Shape Shape
+	// Angle is the rotation of the Shape in radians, counterclockwise in screen coordinates.
+	Angle float64
+	// AngularVel is how many radians per second Angle changes.
+	AngularVel float64
+	// FixedRotation stops collisions and torque from turning the Body.
+	FixedRotation bool
+	// Restitution is how bouncy the Body is, from 0 for no bounce to 1 for a perfect
+	// bounce. The larger of the two bodies' values is used for a collision.
+	Restitution float64
+	// Friction is how much the Body resists sliding, usually between 0 and 1. The geometric
+	// mean of the two bodies' values is used for a collision.
+	Friction float64
+	// Layer is the set of bits for the layers this Body is on, and Mask is the set of
+	// layers it collides with. Two bodies only collide if each one's Layer has a bit in the
+	// other's Mask.
+	Layer, Mask uint32
+	// Data is for the user to associate anything with the Body, such as the game object it
+	// belongs to.
+	Data interface{}
+
+	id       int
+	torque   float64
+	sleeping bool
+	still    time.Duration
+	// pushVel and pushAngularVel are used while solving contacts to move the Body without
+	// changing its velocity.
+	pushVel        geo.Vec
+	pushAngularVel float64
+}
+
+// NewBody creates an awake Body at pos with a Mass equal to the area of its Shape, Friction
+// of 0.5, no Restitution and that is on layer 1 and collides with every layer.
+func NewBody(t BodyType, shape Shape, pos geo.Vec) *Body {
+	b := &Body{
+		Type:     t,
+		Shape:    shape,
+		Friction: 0.5,
+		Layer:    1,
+		Mask:     math.MaxUint32,
+	}
+	b.Pos = pos
+	b.Mass = shape.Area()
+	return b
+}
+
+// ApplyTorque turns the Body. Like ApplyForce it takes effect on the next Update and does
+// not persist after that. A positive torque turns counterclockwise in screen coordinates.
+func (b *Body) ApplyTorque(torque float64) {
+	b.torque += torque
+}
+
+// ApplyForceAt applies force at the point p in world coordinates, which turns the Body as
+// well as pushing it unless p is the Body's center.
+func (b *Body) ApplyForceAt(force, p geo.Vec) {
+	b.ApplyForce(force)
+	b.ApplyTorque(cross(p.Minus(b.Pos), force))
+}
+
+// ApplyImpulse instantly changes the velocity of a Dynamic Body as if it were hit at the
+// point p in world coordinates. It also wakes the Body.
+func (b *Body) ApplyImpulse(impulse, p geo.Vec) {
+	if b.Type != Dynamic {
+		return
+	}
+	b.Wake()
+	b.Vel.Add(impulse.Times(b.invMass()))
+	b.AngularVel += b.invInertia() * cross(p.Minus(b.Pos), impulse)
+}
+
+// Inertia returns the Body's moment of inertia, its resistance to being turned, which
+// depends on its Mass and Shape.
+func (b *Body) Inertia() float64 {
+	return b.Shape.inertia(b.Mass)
+}
+
+// Sleeping returns true if the Body is asleep. Sleeping bodies aren't moved by the World
+// until something wakes them, which saves time when many bodies are at rest.
+func (b *Body) Sleeping() bool {
+	return b.sleeping
+}
+
+// Wake wakes the Body if it is asleep. Bodies wake up when hit, but need to be woken if
+// they are moved or have forces applied some other way.
+func (b *Body) Wake() {
+	b.sleeping = false
+	b.still = 0
+}
+
+// Circle returns the circle covering the Shape in world coordinates. For a Circle Shape it
+// is exact, otherwise it is the smallest circle around Pos that contains the Shape.
+func (b *Body) Circle() geo.Circle {
+	if c, ok := b.Shape.(*Circle); ok {
+		return geo.Circle{X: b.Pos.X, Y: b.Pos.Y, R: c.R}
+	}
+	r := 0.0
+	for _, v := range b.Polygon() {
+		r = math.Max(r, v.Dist(b.Pos))
+	}
+	return geo.Circle{X: b.Pos.X, Y: b.Pos.Y, R: r}
+}
+
+// Polygon returns the outline of a Rect or Polygon Shape in world coordinates, or nil for
+// a Circle.
+func (b *Body) Polygon() geo.Polygon {
+	var local geo.Polygon
+	switch s := b.Shape.(type) {
+	case *Rect:
+		local = geo.RectPolygon(geo.Rect{X: -s.W / 2, Y: -s.H / 2, W: s.W, H: s.H})
+	case *Polygon:
+		local = s.Vertices
+	default:
+		return nil
+	}
+	p := make(geo.Polygon, len(local))
+	for i, v := range local {
+		p[i] = v.Rotated(b.Angle).Plus(b.Pos)
+	}
+	return p
+}
+
+// Bounds returns the smallest Rect containing the Shape in world coordinates.
+func (b *Body) Bounds() geo.Rect {
+	if p := b.Polygon(); p != nil {
+		return p.Bounds()
+	}
+	return b.Circle().Bounds()
+}
+
+func (b *Body) invMass() float64 {
+	if b.Type != Dynamic || b.sleeping || b.Mass <= 0 {
+		return 0
+	}
+	return 1 / b.Mass
+}
+
+func (b *Body) invInertia() float64 {
+	if b.Type != Dynamic || b.sleeping || b.FixedRotation {
+		return 0
+	}
+	if i := b.Inertia(); i > 0 {
+		return 1 / i
+	}
+	return 0
+}
+
+// velAt returns the velocity of the point at offset r from the Body's center.
+func (b *Body) velAt(r geo.Vec) geo.Vec {
+	return pointVel(b.Vel, b.AngularVel, r)
+}
+
+// pointVel returns the velocity of the point at offset r from the center of something
+// moving at vel and turning at angularVel.
+func pointVel(vel geo.Vec, angularVel float64, r geo.Vec) geo.Vec {
+	// Turning counterclockwise on screen moves a point to the right of center up.
+	return vel.Plus(geo.Vec{X: angularVel * r.Y, Y: -angularVel * r.X})
+}
+
+// cross returns the torque of force f applied at offset r, positive for counterclockwise
+// in screen coordinates.
+func cross(r, f geo.Vec) float64 {
+	return r.Y*f.X - r.X*f.Y
+}
@@ -0,0 +1,294 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const (
+	// correction is the fraction of the overlap between bodies removed each Update.
+	// Removing all of it at once makes resting bodies jitter.
+	correction = 0.4
+	// slop is how far bodies may overlap without being pushed apart.
+	slop = 0.01
+)
+
+// Contact describes two bodies that are touching.
+type Contact struct {
+	A, B *Body
+	// Normal is the unit vector pointing from A towards B.
+	Normal geo.Vec
+	// Depth is how far the bodies overlap along Normal.
+	Depth float64
+	// Points are where the bodies touch in world coordinates, either 1 or 2 of them.
+	Points []geo.Vec
+	// Begin is true if the bodies weren't touching in the last Update.
+	Begin bool
+
+	points []contactPoint
+}
+
+// contactPoint is the solver's state for one of a Contact's Points.
+type contactPoint struct {
+	// depth is how far the bodies overlap at the point.
+	depth float64
+	// rA and rB are the offsets of the point from the bodies' centers.
+	rA, rB geo.Vec
+	// normalMass and tangentMass are the bodies' effective masses for an impulse at the
+	// point along the normal and tangent.
+	normalMass, tangentMass float64
+	// bounce is the velocity along the normal that restitution aims for.
+	bounce float64
+	// push is the velocity along the normal that removes the overlap.
+	push float64
+	// normalImpulse and tangentImpulse are the total impulses applied so far, and
+	// pushImpulse is the total used to remove the overlap.
+	normalImpulse, tangentImpulse, pushImpulse float64
+}
+
+// collide returns the Contact between a and b, or nil if they aren't touching.
+func collide(a, b *Body) *Contact {
+	c := &Contact{A: a, B: b}
+	var depths []float64
+	pa, pb := a.Polygon(), b.Polygon()
+	switch {
+	case pa == nil && pb == nil:
+		ca := a.Circle()
+		c.Depth, c.Normal = ca.PenetrationCircle(b.Circle())
+		c.Points = []geo.Vec{a.Pos.Plus(c.Normal.Times(ca.R - c.Depth/2))}
+	case pa == nil:
+		ca := a.Circle()
+		c.Depth, c.Normal = ca.PenetrationPolygon(pb)
+		c.Points = []geo.Vec{a.Pos.Plus(c.Normal.Times(ca.R - c.Depth))}
+	case pb == nil:
+		cb := b.Circle()
+		c.Depth, c.Normal = pa.PenetrationCircle(cb)
+		c.Points = []geo.Vec{b.Pos.Minus(c.Normal.Times(cb.R - c.Depth))}
+	default:
+		c.Depth, c.Normal = pa.PenetrationPolygon(pb)
+		c.Points, depths = clipPoints(pa, pb, c.Normal)
+	}
+	if c.Depth <= 0 {
+		return nil
+	}
+	if len(c.Points) == 0 {
+		c.Points = []geo.Vec{pa.Centroid().Plus(pb.Centroid()).Times(0.5)}
+	}
+	c.points = make([]contactPoint, len(c.Points))
+	for i := range c.points {
+		c.points[i].depth = c.Depth
+		if depths != nil {
+			c.points[i].depth = depths[i]
+		}
+	}
+	return c
+}
+
+// edge is the edge from a to b of a polygon, where max is whichever of them is furthest
+// along the direction the edge was chosen for.
+type edge struct {
+	max, a, b geo.Vec
+}
+
+// bestEdge returns the edge of the convex polygon p that faces most directly along n.
+func bestEdge(p geo.Polygon, n geo.Vec) edge {
+	i := 0
+	for j := range p {
+		if p[j].Dot(n) > p[i].Dot(n) {
+			i = j
+		}
+	}
+	v, prev, next := p[i], p[(i+len(p)-1)%len(p)], p[(i+1)%len(p)]
+	// Of the two edges that meet at v, the one that is most perpendicular to n faces it.
+	if v.Minus(prev).Normalized().Dot(n) <= v.Minus(next).Normalized().Dot(n) {
+		return edge{max: v, a: prev, b: v}
+	}
+	return edge{max: v, a: v, b: next}
+}
+
+// clipPoints returns the points where the convex polygons touch, and how deep each one is,
+// given the normal from pa towards pb. The edge that faces the other polygon most directly
+// is the reference edge and the points are those of the other polygon's facing edge that
+// are clipped to the sides of the reference edge and are behind it.
+func clipPoints(pa, pb geo.Polygon, n geo.Vec) (points []geo.Vec, depths []float64) {
+	ref, inc := bestEdge(pa, n), bestEdge(pb, n.Times(-1))
+	refNormal := n
+	refDir, incDir := ref.b.Minus(ref.a).Normalized(), inc.b.Minus(inc.a).Normalized()
+	// Prefer pa's edge when they're close so that the choice doesn't flip back and forth
+	// between Updates, such as for a stack of boxes.
+	if math.Abs(refDir.Dot(n)) > math.Abs(incDir.Dot(n))+1e-3 {
+		ref, inc = inc, ref
+		refNormal = n.Times(-1)
+		refDir = incDir
+	}
+
+	clipped := clip(inc.a, inc.b, refDir, refDir.Dot(ref.a))
+	if len(clipped) < 2 {
+		return nil, nil
+	}
+	clipped = clip(clipped[0], clipped[1], refDir.Times(-1), -refDir.Dot(ref.b))
+	if len(clipped) < 2 {
+		return nil, nil
+	}
+
+	face := geo.Vec{X: -refDir.Y, Y: refDir.X}
+	if face.Dot(refNormal) < 0 {
+		face.Mul(-1)
+	}
+	max := face.Dot(ref.max)
+	for _, p := range clipped {
+		if d := max - face.Dot(p); d >= 0 {
+			points = append(points, p)
+			depths = append(depths, d)
+		}
+	}
+	return points, depths
+}
+
+// clip returns the part of the segment from v1 to v2 that is at least o along n.
+func clip(v1, v2, n geo.Vec, o float64) []geo.Vec {
+	var points []geo.Vec
+	d1, d2 := n.Dot(v1)-o, n.Dot(v2)-o
+	if d1 >= 0 {
+		points = append(points, v1)
+	}
+	if d2 >= 0 {
+		points = append(points, v2)
+	}
+	if d1*d2 < 0 {
+		points = append(points, v1.Plus(v2.Minus(v1).Times(d1/(d1-d2))))
+	}
+	return points
+}
+
+// solve pushes apart the bodies of each Contact using sequential impulses. Their velocities
+// are changed so that they stop moving into each other, and separately they are moved so
+// that they don't overlap without that movement adding to their velocities.
+func (w *World) solve(contacts []*Contact, sec float64) {
+	if sec <= 0 {
+		return
+	}
+	// Anything slower than this is treated as resting so that it doesn't bounce forever.
+	bounceSpeed := 2 * w.Gravity.Len() * sec
+	for _, c := range contacts {
+		c.prepare(bounceSpeed, sec)
+	}
+	for i := 0; i < w.Iterations; i++ {
+		for _, c := range contacts {
+			c.solveVelocity()
+		}
+	}
+	for i := 0; i < w.Iterations; i++ {
+		for _, c := range contacts {
+			c.solvePush()
+		}
+	}
+	for _, c := range contacts {
+		for _, b := range []*Body{c.A, c.B} {
+			b.Pos.Add(b.pushVel.Times(sec))
+			b.Angle += b.pushAngularVel * sec
+			b.pushVel, b.pushAngularVel = geo.Vec{}, 0
+		}
+	}
+}
+
+// warmStart copies the impulses from the same bodies' Contact in the last Update, which
+// is usually a good guess for this one and helps stacks of bodies settle.
+func (c *Contact) warmStart(last *Contact) {
+	if len(last.points) != len(c.points) {
+		return
+	}
+	for i, p := range c.Points {
+		j := 0
+		for k, q := range last.Points {
+			if q.Dist2(p) < last.Points[j].Dist2(p) {
+				j = k
+			}
+		}
+		c.points[i].normalImpulse = last.points[j].normalImpulse
+		c.points[i].tangentImpulse = last.points[j].tangentImpulse
+	}
+}
+
+func (c *Contact) prepare(bounceSpeed, sec float64) {
+	a, b := c.A, c.B
+	restitution := math.Max(a.Restitution, b.Restitution)
+	tangent := geo.Vec{X: -c.Normal.Y, Y: c.Normal.X}
+	for i, p := range c.Points {
+		cp := &c.points[i]
+		cp.rA, cp.rB = p.Minus(a.Pos), p.Minus(b.Pos)
+		cp.normalMass = c.mass(cp, c.Normal)
+		cp.tangentMass = c.mass(cp, tangent)
+		cp.push = correction / sec * math.Max(cp.depth-slop, 0)
+		if vn := c.relVel(cp).Dot(c.Normal); vn < -bounceSpeed {
+			cp.bounce = -restitution * vn
+		}
+		c.impulse(cp, c.Normal.Times(cp.normalImpulse).Plus(tangent.Times(cp.tangentImpulse)))
+	}
+}
+
+// mass returns the bodies' combined effective mass for an impulse at cp along dir, or 0 if
+// neither can be moved.
+func (c *Contact) mass(cp *contactPoint, dir geo.Vec) float64 {
+	rnA, rnB := cross(cp.rA, dir), cross(cp.rB, dir)
+	inv := c.A.invMass() + c.B.invMass() + c.A.invInertia()*rnA*rnA + c.B.invInertia()*rnB*rnB
+	if inv == 0 {
+		return 0
+	}
+	return 1 / inv
+}
+
+// relVel returns the velocity of B relative to A at cp.
+func (c *Contact) relVel(cp *contactPoint) geo.Vec {
+	return c.B.velAt(cp.rB).Minus(c.A.velAt(cp.rA))
+}
+
+// impulse applies impulse to B at cp and the opposite to A.
+func (c *Contact) impulse(cp *contactPoint, impulse geo.Vec) {
+	a, b := c.A, c.B
+	a.Vel.Sub(impulse.Times(a.invMass()))
+	a.AngularVel -= a.invInertia() * cross(cp.rA, impulse)
+	b.Vel.Add(impulse.Times(b.invMass()))
+	b.AngularVel += b.invInertia() * cross(cp.rB, impulse)
+}
+
+func (c *Contact) solveVelocity() {
+	friction := math.Sqrt(c.A.Friction * c.B.Friction)
+	tangent := geo.Vec{X: -c.Normal.Y, Y: c.Normal.X}
+	for i := range c.points {
+		cp := &c.points[i]
+
+		// The total impulse along the normal can only push the bodies apart, never pull.
+		vn := c.relVel(cp).Dot(c.Normal)
+		total := math.Max(cp.normalImpulse+(cp.bounce-vn)*cp.normalMass, 0)
+		c.impulse(cp, c.Normal.Times(total-cp.normalImpulse))
+		cp.normalImpulse = total
+
+		// Friction can't be stronger than the impulse pressing the bodies together.
+		vt := c.relVel(cp).Dot(tangent)
+		limit := friction * cp.normalImpulse
+		total = math.Max(-limit, math.Min(cp.tangentImpulse-vt*cp.tangentMass, limit))
+		c.impulse(cp, tangent.Times(total-cp.tangentImpulse))
+		cp.tangentImpulse = total
+	}
+}
+
+// solvePush is like solveVelocity without friction, but for the velocities that remove the
+// overlap.
+func (c *Contact) solvePush() {
+	a, b := c.A, c.B
+	for i := range c.points {
+		cp := &c.points[i]
+		vA := pointVel(a.pushVel, a.pushAngularVel, cp.rA)
+		vB := pointVel(b.pushVel, b.pushAngularVel, cp.rB)
+		vn := vB.Minus(vA).Dot(c.Normal)
+		total := math.Max(cp.pushImpulse+(cp.push-vn)*cp.normalMass, 0)
+		impulse := c.Normal.Times(total - cp.pushImpulse)
+		cp.pushImpulse = total
+		a.pushVel.Sub(impulse.Times(a.invMass()))
+		a.pushAngularVel -= a.invInertia() * cross(cp.rA, impulse)
+		b.pushVel.Add(impulse.Times(b.invMass()))
+		b.pushAngularVel += b.invInertia() * cross(cp.rB, impulse)
+	}
+}
@@ -0,0 +1,314 @@
+// Package physics simulates 2D rigid bodies. A Body is a particle.Particle with a Shape
+// that can rotate, and a World moves its bodies and keeps them from passing through each
+// other, bouncing and sliding according to their Restitution and Friction.
+//
+// A typical setup might look like:
+//
+//	world := physics.NewWorld(geo.Vec{Y: 500})
+//	ground := physics.NewBody(physics.Static, &physics.Rect{W: 800, H: 20}, geo.Vec{X: 400, Y: 590})
+//	ball := physics.NewBody(physics.Dynamic, &physics.Circle{R: 10}, geo.Vec{X: 400, Y: 100})
+//	ball.Restitution = 0.8
+//	world.Add(ground)
+//	world.Add(ball)
+//	...
+//	world.Update(dt)
+//	// Draw ball.Circle() and ground.Polygon().
+package physics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Bredgren/gogame/broadphase"
+	"github.com/Bredgren/gogame/geo"
+)
+
+// World holds bodies and moves them. Use NewWorld to create one.
+type World struct {
+	// Gravity is the acceleration applied to every Dynamic Body, in units per second per
+	// second.
+	Gravity geo.Vec
+	// Iterations is how many times per Update the contacts are resolved. More iterations
+	// make stacks of bodies more stable but take longer.
+	Iterations int
+
+	// SleepTime is how long a Body must be nearly still before it falls asleep. If it is 0
+	// then bodies never sleep.
+	SleepTime time.Duration
+	// SleepSpeed is the speed, in units per second, below which a Body is nearly still.
+	SleepSpeed float64
+	// SleepAngularSpeed is the speed, in radians per second, below which a Body is nearly
+	// still.
+	SleepAngularSpeed float64
+
+	// OnContact, if not nil, is called for every pair of bodies that are touching during
+	// Update, before they are pushed apart. If it returns false the contact is ignored for
+	// this Update, which can be used for triggers or one-way platforms.
+	OnContact func(c *Contact) bool
+	// OnSeparate, if not nil, is called during Update for every pair of bodies that were
+	// touching in the last Update but aren't anymore.
+	OnSeparate func(a, b *Body)
+
+	// Broadphase finds which bodies might be touching. It can be replaced, with a
+	// broadphase.Quadtree for example, before any bodies are added.
+	Broadphase broadphase.Index
+
+	bodies   []*Body
+	ids      map[int]*Body
+	nextID   int
+	touching map[broadphase.Pair]*Contact
+}
+
+// NewWorld creates an empty World with the given Gravity. Bodies fall asleep after being
+// nearly still for half a second.
+func NewWorld(gravity geo.Vec) *World {
+	return &World{
+		Gravity:           gravity,
+		Iterations:        8,
+		SleepTime:         500 * time.Millisecond,
+		SleepSpeed:        1,
+		SleepAngularSpeed: 0.05,
+		Broadphase:        broadphase.NewSpatialHash(64),
+		ids:               make(map[int]*Body),
+		nextID:            1,
+		touching:          make(map[broadphase.Pair]*Contact),
+	}
+}
+
+// Add puts b in the World. Adding a Body that is already in the World does nothing.
+func (w *World) Add(b *Body) {
+	if w.ids[b.id] == b {
+		return
+	}
+	b.id = w.nextID
+	w.nextID++
+	w.ids[b.id] = b
+	w.bodies = append(w.bodies, b)
+	w.Broadphase.Insert(b.id, b.Bounds())
+}
+
+// Remove takes b out of the World. Removing a Body that isn't in the World does nothing.
+func (w *World) Remove(b *Body) {
+	if w.ids[b.id] != b {
+		return
+	}
+	delete(w.ids, b.id)
+	w.Broadphase.Remove(b.id)
+	for p := range w.touching {
+		if p.A == b.id || p.B == b.id {
+			delete(w.touching, p)
+		}
+	}
+	for i, other := range w.bodies {
+		if other == b {
+			w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+			break
+		}
+	}
+}
+
+// Bodies returns all bodies in the World in the order they were added.
+func (w *World) Bodies() []*Body {
+	return append([]*Body(nil), w.bodies...)
+}
+
+// QueryRect returns the bodies whose Bounds collide with r.
+func (w *World) QueryRect(r geo.Rect) []*Body {
+	return w.lookup(w.Broadphase.QueryRect(r))
+}
+
+// QueryPoint returns the bodies whose Shape contains p.
+func (w *World) QueryPoint(p geo.Vec) []*Body {
+	var found []*Body
+	for _, b := range w.lookup(w.Broadphase.QueryPoint(p.X, p.Y)) {
+		if poly := b.Polygon(); poly != nil {
+			if poly.CollidePoint(p.X, p.Y) {
+				found = append(found, b)
+			}
+		} else if b.Circle().CollidePoint(p.X, p.Y) {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+// lookup returns the bodies for the given IDs in the order they were added.
+func (w *World) lookup(ids []int) []*Body {
+	sort.Ints(ids)
+	bodies := make([]*Body, len(ids))
+	for i, id := range ids {
+		bodies[i] = w.ids[id]
+	}
+	return bodies
+}
+
+// Update advances the World by dt. Each Body is moved by its velocities and any forces
+// applied since the last Update, then touching bodies are pushed apart and bodies that have
+// been still for long enough fall asleep.
+func (w *World) Update(dt time.Duration) {
+	for _, b := range w.bodies {
+		w.integrate(b, dt)
+		w.Broadphase.Update(b.id, b.Bounds())
+	}
+	contacts := w.findContacts()
+	w.solve(contacts, dt.Seconds())
+	w.sleep(contacts, dt)
+}
+
+func (w *World) integrate(b *Body, dt time.Duration) {
+	sec := dt.Seconds()
+	switch {
+	case b.Type == Dynamic && !b.sleeping:
+		b.Vel.Add(w.Gravity.Times(sec))
+		b.AngularVel += b.torque * b.invInertia() * sec
+		b.Particle.Update(dt)
+		b.Angle += b.AngularVel * sec
+	case b.Type == Kinematic:
+		b.Pos.Add(b.Vel.Times(sec))
+		b.Angle += b.AngularVel * sec
+		// Updating by 0 clears any applied forces without using them.
+		b.Particle.Update(0)
+	default:
+		b.Particle.Update(0)
+	}
+	b.torque = 0
+}
+
+// findContacts returns the contacts that need resolving, calling the callbacks along the
+// way.
+func (w *World) findContacts() []*Contact {
+	pairs := w.Broadphase.Pairs()
+	sortPairs(pairs)
+
+	var contacts []*Contact
+	touching := make(map[broadphase.Pair]*Contact)
+	for _, p := range pairs {
+		a, b := w.ids[p.A], w.ids[p.B]
+		if !w.canCollide(a, b) {
+			// Sleeping bodies are still touching what they were when they fell asleep.
+			if last := w.touching[p]; last != nil && (a.sleeping || b.sleeping) {
+				touching[p] = last
+			}
+			continue
+		}
+		c := collide(a, b)
+		if c == nil {
+			continue
+		}
+		touching[p] = c
+		if last := w.touching[p]; last != nil {
+			c.warmStart(last)
+		} else {
+			c.Begin = true
+		}
+		if w.OnContact != nil && !w.OnContact(c) {
+			c.points = nil
+			continue
+		}
+		// Touching bodies fall asleep together, so touching an awake Body means something
+		// has changed.
+		if a.sleeping && w.active(b) {
+			a.Wake()
+		}
+		if b.sleeping && w.active(a) {
+			b.Wake()
+		}
+		contacts = append(contacts, c)
+	}
+
+	if w.OnSeparate != nil {
+		var separated []broadphase.Pair
+		for p := range w.touching {
+			if touching[p] == nil {
+				separated = append(separated, p)
+			}
+		}
+		sortPairs(separated)
+		for _, p := range separated {
+			w.OnSeparate(w.ids[p.A], w.ids[p.B])
+		}
+	}
+	w.touching = touching
+	return contacts
+}
+
+// sortPairs sorts pairs by ID so that Update happens in the same order every time.
+func sortPairs(pairs []broadphase.Pair) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+}
+
+// canCollide returns true if the bodies' layers allow them to collide and at least one of
+// them can be moved by the collision.
+func (w *World) canCollide(a, b *Body) bool {
+	if a.Layer&b.Mask == 0 || b.Layer&a.Mask == 0 {
+		return false
+	}
+	if a.Type != Dynamic && b.Type != Dynamic {
+		return false
+	}
+	return w.active(a) || w.active(b)
+}
+
+// active returns true if b is an awake Dynamic Body or a Kinematic Body that is moving.
+func (w *World) active(b *Body) bool {
+	switch b.Type {
+	case Dynamic:
+		return !b.sleeping
+	case Kinematic:
+		return b.Vel != geo.Vec{} || b.AngularVel != 0
+	}
+	return false
+}
+
+// sleep puts bodies to sleep once they and every Dynamic Body they touch, directly or
+// through others, have been nearly still for SleepTime. Letting one Body in a stack sleep
+// on its own would make it immovable to the others.
+func (w *World) sleep(contacts []*Contact, dt time.Duration) {
+	if w.SleepTime <= 0 {
+		return
+	}
+	group := make(map[*Body]*Body)
+	var find func(b *Body) *Body
+	find = func(b *Body) *Body {
+		parent, ok := group[b]
+		if !ok || parent == b {
+			return b
+		}
+		root := find(parent)
+		group[b] = root
+		return root
+	}
+	for _, c := range contacts {
+		if c.A.Type == Dynamic && c.B.Type == Dynamic {
+			group[find(c.A)] = find(c.B)
+		}
+	}
+
+	awake := make(map[*Body]bool)
+	for _, b := range w.bodies {
+		if b.Type != Dynamic || b.sleeping {
+			continue
+		}
+		if b.Vel.Len() > w.SleepSpeed || math.Abs(b.AngularVel) > w.SleepAngularSpeed {
+			b.still = 0
+		} else {
+			b.still += dt
+		}
+		if b.still < w.SleepTime {
+			awake[find(b)] = true
+		}
+	}
+	for _, b := range w.bodies {
+		if b.Type == Dynamic && !b.sleeping && !awake[find(b)] {
+			b.sleeping = true
+			b.Vel = geo.Vec{}
+			b.AngularVel = 0
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package physics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const e = 1e-10
+
+const frame = time.Second / 60
+
+// newGround returns a World with gravity and a static floor whose top is at y=100.
+func newGround() (*World, *Body) {
+	w := NewWorld(geo.Vec{Y: 500})
+	ground := NewBody(Static, &Rect{W: 400, H: 20}, geo.Vec{Y: 110})
+	w.Add(ground)
+	return w, ground
+}
+
+func run(w *World, d time.Duration) {
+	for t := time.Duration(0); t < d; t += frame {
+		w.Update(frame)
+	}
+}
+
+func TestInertia(t *testing.T) {
+	cases := []struct {
+		shape Shape
+		want  float64
+	}{
+		{&Circle{R: 2}, 4 * math.Pi * 4 / 2},
+		{&Rect{W: 2, H: 4}, 8 * 20 / 12.0},
+		// The same rect as a Polygon.
+		{&Polygon{Vertices: geo.Polygon{{X: -1, Y: -2}, {X: 1, Y: -2}, {X: 1, Y: 2}, {X: -1, Y: 2}}}, 8 * 20 / 12.0},
+	}
+
+	for i, c := range cases {
+		b := NewBody(Dynamic, c.shape, geo.Vec{})
+		if got := b.Inertia(); math.Abs(got-c.want) > e {
+			t.Errorf("case %d: got %v, want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestRest(t *testing.T) {
+	cases := []struct {
+		shape Shape
+		angle float64
+		// bottom is the distance from the center to the bottom of the shape once it lands.
+		bottom float64
+	}{
+		{&Circle{R: 10}, 0, 10},
+		{&Rect{W: 20, H: 10}, 0, 5},
+		{&Polygon{Vertices: geo.Polygon{{X: -10, Y: 5}, {X: 0, Y: -10}, {X: 10, Y: 5}}}, 0, 5},
+		// Landing on a corner tips it over onto a side.
+		{&Rect{W: 20, H: 20}, 0.3, 10},
+	}
+
+	for i, c := range cases {
+		w, _ := newGround()
+		b := NewBody(Dynamic, c.shape, geo.Vec{Y: 50})
+		b.Angle = c.angle
+		w.Add(b)
+		run(w, 5*time.Second)
+		if want := 100 - c.bottom; math.Abs(b.Pos.Y-want) > 1 {
+			t.Errorf("case %d: got y %v, want %v", i, b.Pos.Y, want)
+		}
+		if !b.Sleeping() {
+			t.Errorf("case %d: still awake with velocity %#v, %v", i, b.Vel, b.AngularVel)
+		}
+		if b.Bounds().Bottom() > 101 {
+			t.Errorf("case %d: sank to %v", i, b.Bounds().Bottom())
+		}
+	}
+}
+
+func TestStack(t *testing.T) {
+	w, _ := newGround()
+	var boxes []*Body
+	for i := 0; i < 5; i++ {
+		b := NewBody(Dynamic, &Rect{W: 20, H: 20}, geo.Vec{Y: 89 - float64(i)*21})
+		w.Add(b)
+		boxes = append(boxes, b)
+	}
+	run(w, 5*time.Second)
+	for i, b := range boxes {
+		want := geo.Vec{Y: 90 - float64(i)*20}
+		if !b.Pos.Equals(want, 1) || math.Abs(b.Angle) > 0.02 {
+			t.Errorf("box %d: got %#v at %v, want %#v", i, b.Pos, b.Angle, want)
+		}
+		if !b.Sleeping() {
+			t.Errorf("box %d: still awake", i)
+		}
+	}
+
+	// Knocking the bottom box out wakes the ones on top.
+	boxes[0].ApplyImpulse(geo.Vec{X: 400 * 1000}, boxes[0].Pos)
+	w.Update(frame)
+	if boxes[1].Sleeping() {
+		t.Errorf("box 1 didn't wake")
+	}
+}
+
+func TestBounce(t *testing.T) {
+	cases := []struct {
+		restitution float64
+		velA, velB  geo.Vec
+	}{
+		{1, geo.Vec{}, geo.Vec{X: 100}},
+		{0, geo.Vec{X: 50}, geo.Vec{X: 50}},
+		{0.5, geo.Vec{X: 25}, geo.Vec{X: 75}},
+	}
+
+	for i, c := range cases {
+		w := NewWorld(geo.Vec{})
+		a := NewBody(Dynamic, &Circle{R: 10}, geo.Vec{X: 0})
+		b := NewBody(Dynamic, &Circle{R: 10}, geo.Vec{X: 21})
+		a.Vel = geo.Vec{X: 100}
+		a.Restitution = c.restitution
+		w.Add(a)
+		w.Add(b)
+		run(w, time.Second/10)
+		if !a.Vel.Equals(c.velA, e) || !b.Vel.Equals(c.velB, e) {
+			t.Errorf("case %d: got %#v, %#v, want %#v, %#v", i, a.Vel, b.Vel, c.velA, c.velB)
+		}
+		if a.AngularVel != 0 || b.AngularVel != 0 {
+			t.Errorf("case %d: head on collision made them spin", i)
+		}
+	}
+}
+
+func TestFriction(t *testing.T) {
+	cases := []struct {
+		friction float64
+		// stops is true if the box should come to a stop.
+		stops bool
+	}{
+		{0, false},
+		{0.5, true},
+		{1, true},
+	}
+
+	for i, c := range cases {
+		w, ground := newGround()
+		ground.Friction = c.friction
+		b := NewBody(Dynamic, &Rect{W: 10, H: 10}, geo.Vec{X: -150, Y: 95})
+		b.Friction = c.friction
+		b.Vel = geo.Vec{X: 100}
+		w.Add(b)
+		run(w, 2*time.Second)
+		if stopped := math.Abs(b.Vel.X) < 1; stopped != c.stops {
+			t.Errorf("case %d: got velocity %#v", i, b.Vel)
+		}
+		if math.Abs(b.Angle) > 0.01 {
+			t.Errorf("case %d: box tipped over to %v", i, b.Angle)
+		}
+	}
+
+	// Sliding further with less friction.
+	dist := func(friction float64) float64 {
+		w, ground := newGround()
+		ground.Friction = friction
+		b := NewBody(Dynamic, &Rect{W: 10, H: 10}, geo.Vec{X: -150, Y: 95})
+		b.Friction = friction
+		b.Vel = geo.Vec{X: 200}
+		w.Add(b)
+		run(w, 2*time.Second)
+		return b.Pos.X + 150
+	}
+	if low, high := dist(0.3), dist(0.6); low <= high {
+		t.Errorf("friction 0.3 went %v, 0.6 went %v", low, high)
+	}
+}
+
+func TestSpin(t *testing.T) {
+	w := NewWorld(geo.Vec{})
+	b := NewBody(Dynamic, &Rect{W: 20, H: 20}, geo.Vec{})
+	w.Add(b)
+
+	// Pushing the right side up turns it counterclockwise.
+	b.ApplyImpulse(geo.Vec{Y: -100}, geo.Vec{X: 10})
+	if want := 100 * 10 / b.Inertia(); math.Abs(b.AngularVel-want) > e {
+		t.Errorf("got angular velocity %v, want %v", b.AngularVel, want)
+	}
+	if want := (geo.Vec{Y: -100 / b.Mass}); !b.Vel.Equals(want, e) {
+		t.Errorf("got velocity %#v, want %#v", b.Vel, want)
+	}
+
+	b.Vel, b.AngularVel = geo.Vec{}, 0
+	b.ApplyTorque(b.Inertia())
+	w.Update(time.Second)
+	if b.AngularVel != 1 || b.Angle != 1 {
+		t.Errorf("got angle %v at %v, want 1 at 1", b.Angle, b.AngularVel)
+	}
+	w.Update(time.Second)
+	if b.AngularVel != 1 {
+		t.Errorf("torque persisted, got %v", b.AngularVel)
+	}
+
+	b.FixedRotation = true
+	b.AngularVel = 0
+	b.ApplyForceAt(geo.Vec{Y: -100}, geo.Vec{X: 10})
+	w.Update(time.Second)
+	if b.AngularVel != 0 {
+		t.Errorf("fixed rotation turned to %v", b.AngularVel)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		layer, mask uint32
+		falls       bool
+	}{
+		{1, 1, false},
+		{2, 1, true},
+		{1, 2, true},
+		{3, 2, true},
+		{3, 3, false},
+	}
+
+	for i, c := range cases {
+		w, ground := newGround()
+		ground.Mask = 1
+		b := NewBody(Dynamic, &Circle{R: 10}, geo.Vec{Y: 50})
+		b.Layer, b.Mask = c.layer, c.mask
+		w.Add(b)
+		run(w, time.Second)
+		if fell := b.Pos.Y > 120; fell != c.falls {
+			t.Errorf("case %d: got y %v", i, b.Pos.Y)
+		}
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	w, ground := newGround()
+	ball := NewBody(Dynamic, &Circle{R: 10}, geo.Vec{Y: 50})
+	w.Add(ball)
+	sensor := NewBody(Static, &Rect{W: 20, H: 20}, geo.Vec{Y: 200})
+	w.Add(sensor)
+
+	solid := true
+	begins := map[*Body]int{}
+	separations := 0
+	w.OnContact = func(c *Contact) bool {
+		if c.A != ball && c.B != ball {
+			t.Errorf("contact between %#v and %#v", c.A, c.B)
+		}
+		other := c.A
+		if other == ball {
+			other = c.B
+		}
+		if c.Begin {
+			begins[other]++
+		}
+		if len(c.Points) == 0 || c.Depth <= 0 {
+			t.Errorf("contact %#v has no points or depth", c)
+		}
+		// The normal points from A towards B.
+		if d := c.B.Pos.Minus(c.A.Pos).Dot(c.Normal); d <= 0 {
+			t.Errorf("normal %#v points the wrong way", c.Normal)
+		}
+		return other == ground && solid
+	}
+	w.OnSeparate = func(a, b *Body) {
+		separations++
+	}
+
+	run(w, 2*time.Second)
+	if begins[ground] != 1 || begins[sensor] != 0 || separations != 0 {
+		t.Errorf("landing: got %v begins, %d separations", begins, separations)
+	}
+	if !ball.Sleeping() {
+		t.Errorf("ball should be sleeping")
+	}
+
+	// Dropping the ball through the ground and the sensor.
+	solid = false
+	ball.Wake()
+	run(w, 2*time.Second)
+	if begins[ground] != 1 || begins[sensor] != 1 || separations != 2 {
+		t.Errorf("dropping: got %v begins, %d separations", begins, separations)
+	}
+}
+
+func TestKinematic(t *testing.T) {
+	w := NewWorld(geo.Vec{Y: 500})
+	platform := NewBody(Kinematic, &Rect{W: 100, H: 20}, geo.Vec{Y: 110})
+	platform.Vel = geo.Vec{X: 20}
+	w.Add(platform)
+	box := NewBody(Dynamic, &Rect{W: 10, H: 10}, geo.Vec{Y: 95})
+	w.Add(box)
+	run(w, 2*time.Second)
+
+	if want := (geo.Vec{X: 20}); platform.Vel != want {
+		t.Errorf("platform velocity changed to %#v", platform.Vel)
+	}
+	// Friction carries the box along with the platform.
+	if math.Abs(box.Pos.X-platform.Pos.X) > 2 || math.Abs(box.Pos.Y-95) > 1 {
+		t.Errorf("box at %#v, platform at %#v", box.Pos, platform.Pos)
+	}
+}
+
+func TestWorldBodies(t *testing.T) {
+	w := NewWorld(geo.Vec{})
+	a := NewBody(Static, &Circle{R: 10}, geo.Vec{})
+	b := NewBody(Static, &Rect{W: 10, H: 10}, geo.Vec{X: 12})
+	c := NewBody(Static, &Circle{R: 10}, geo.Vec{X: 100})
+	w.Add(a)
+	w.Add(b)
+	w.Add(b)
+	w.Add(c)
+	if got := w.Bodies(); len(got) != 3 || got[0] != a || got[1] != b || got[2] != c {
+		t.Errorf("got %v", got)
+	}
+	if got := w.QueryRect(geo.Rect{X: 6, Y: -1, W: 2, H: 2}); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("query rect got %v", got)
+	}
+	// Inside a's bounds but not its circle.
+	if got := w.QueryPoint(geo.Vec{X: -9, Y: -9}); len(got) != 0 {
+		t.Errorf("query point got %v", got)
+	}
+	if got := w.QueryPoint(geo.Vec{X: 8}); len(got) != 2 {
+		t.Errorf("query point got %v", got)
+	}
+
+	w.Remove(b)
+	w.Remove(b)
+	if got := w.Bodies(); len(got) != 2 || got[0] != a || got[1] != c {
+		t.Errorf("after remove got %v", got)
+	}
+	if got := w.QueryPoint(geo.Vec{X: 12}); len(got) != 0 {
+		t.Errorf("query point after remove got %v", got)
+	}
+}
@@ -0,0 +1,109 @@
+package steering
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Obstacle is a convex shape that an Agent can avoid, such as a geo.Circle, geo.Rect or
+// convex geo.Polygon.
+type Obstacle interface {
+	// ClosestPoint returns the point within the shape that is closest to v, which is v
+	// itself if it's inside.
+	ClosestPoint(v geo.Vec) geo.Vec
+}
+
+var (
+	_ Obstacle = geo.Circle{}
+	_ Obstacle = geo.Rect{}
+	_ Obstacle = geo.Polygon{}
+)
+
+// closestIterations is how many times closestPair refines its guess.
+const closestIterations = 8
+
+// closestPair returns the points on s and o that are closest to each other, by projecting
+// back and forth between them, which converges because they're both convex.
+func closestPair(s geo.Segment, o Obstacle) (onSeg, onObstacle geo.Vec) {
+	onSeg = s.A
+	for i := 0; i < closestIterations; i++ {
+		onObstacle = o.ClosestPoint(onSeg)
+		next := s.ClosestPoint(onObstacle)
+		if next == onSeg {
+			break
+		}
+		onSeg = next
+	}
+	return onSeg, onObstacle
+}
+
+// AvoidObstacles returns the force that steers the Agent sideways around the nearest
+// obstacle it would hit within LookAhead at its current velocity, or the zero vector if
+// the way is clear. The closer the obstacle, the stronger the force.
+func (a *Agent) AvoidObstacles(obstacles ...Obstacle) geo.Vec {
+	heading := a.Heading()
+	reach := a.Vel.Len()*a.LookAhead.Seconds() + a.Radius
+	feeler := geo.Segment{A: a.Pos, B: a.Pos.Plus(heading.Times(reach))}
+
+	nearest := math.Inf(1)
+	var away geo.Vec
+	for _, o := range obstacles {
+		onSeg, onObstacle := closestPair(feeler, o)
+		if onSeg.Dist(onObstacle) >= a.Radius {
+			continue
+		}
+		if d := onSeg.Dist(a.Pos); d < nearest {
+			nearest = d
+			away = onSeg.Minus(onObstacle)
+			if away.Len2() == 0 {
+				// The feeler goes through the obstacle so go around the side the Agent is on.
+				away = a.Pos.Minus(onObstacle)
+			}
+		}
+	}
+	if math.IsInf(nearest, 1) {
+		return geo.Vec{}
+	}
+
+	// Only turn, since pushing along the heading would just speed up or slow down.
+	side := away.Minus(heading.Times(away.Dot(heading)))
+	if side.Len2() < 1e-12 {
+		side = geo.Vec{X: heading.Y, Y: -heading.X}
+	}
+	return side.WithLen(a.MaxForce * (1 - nearest/reach))
+}
+
+// FollowPath returns the force that steers the Agent along path, a sequence of points such
+// as from path.FindPath. The Agent aims for the point on the path a LookAhead's worth of
+// travel beyond the point nearest to where it will be, and arrives at the last point.
+func (a *Agent) FollowPath(path []geo.Vec) geo.Vec {
+	ahead := a.MaxSpeed * a.LookAhead.Seconds()
+	switch len(path) {
+	case 0:
+		return geo.Vec{}
+	case 1:
+		return a.Arrive(path[0], ahead)
+	}
+
+	future := a.Pos.Plus(a.Vel.Times(a.LookAhead.Seconds()))
+	seg, nearest := 0, path[0]
+	for i := 0; i+1 < len(path); i++ {
+		p := geo.Segment{A: path[i], B: path[i+1]}.ClosestPoint(future)
+		if p.Dist2(future) < nearest.Dist2(future) {
+			seg, nearest = i, p
+		}
+	}
+
+	// Walk ahead along the path from the nearest point.
+	target := nearest
+	for left := ahead; seg+1 < len(path); seg++ {
+		d := path[seg+1].Dist(target)
+		if d > left {
+			return a.Seek(target.Plus(path[seg+1].Minus(target).WithLen(left)))
+		}
+		left -= d
+		target = path[seg+1]
+	}
+	return a.Arrive(path[len(path)-1], ahead)
+}
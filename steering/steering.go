@@ -0,0 +1,196 @@
+// Package steering moves autonomous agents around in a lifelike way using Craig Reynolds'
+// steering behaviors. Each behavior returns a steering force that is combined with others
+// using Blend or Prioritize then applied with Agent.Steer.
+//
+// An enemy that chases the player while keeping away from walls might look like:
+//
+//	force := enemy.Prioritize(
+//		enemy.AvoidObstacles(walls...),
+//		enemy.Pursue(&player.Particle),
+//	)
+//	enemy.Steer(force)
+//	enemy.Update(dt)
+package steering
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+	"github.com/Bredgren/gogame/particle"
+)
+
+// Agent is a Particle that steers itself. Use NewAgent to create one.
+type Agent struct {
+	particle.Particle
+	// MaxSpeed is the fastest the Agent can move, in units per second.
+	MaxSpeed float64
+	// MaxForce is the strongest steering force the Agent can apply. Lower values make it
+	// turn and stop more slowly.
+	MaxForce float64
+	// Radius is the size of the Agent, used to avoid obstacles.
+	Radius float64
+	// LookAhead is how far into the future the Agent looks when avoiding obstacles and
+	// following paths.
+	LookAhead time.Duration
+
+	// WanderDistance, WanderRadius and WanderJitter control Wander. The Agent steers
+	// towards a point on a circle of WanderRadius that is WanderDistance in front of it. The
+	// point moves randomly around the circle by up to WanderJitter radians per second.
+	WanderDistance, WanderRadius, WanderJitter float64
+	// Rng, if not nil, is used for Wander instead of the global source in math/rand that the
+	// geo package's random functions use.
+	Rng *geo.Rng
+
+	heading     geo.Vec
+	wanderAngle float64
+}
+
+// NewAgent creates an Agent at pos facing right with a Mass and Radius of 1 and a LookAhead
+// of half a second. It wanders by steering towards a circle with a radius of half its
+// MaxSpeed, one MaxSpeed in front of it.
+func NewAgent(pos geo.Vec, maxSpeed, maxForce float64) *Agent {
+	a := &Agent{
+		MaxSpeed:       maxSpeed,
+		MaxForce:       maxForce,
+		Radius:         1,
+		LookAhead:      time.Second / 2,
+		WanderDistance: maxSpeed,
+		WanderRadius:   maxSpeed / 2,
+		WanderJitter:   math.Pi,
+		heading:        geo.Vec{X: 1},
+	}
+	a.Pos = pos
+	a.Mass = 1
+	return a
+}
+
+// Heading returns the unit vector in the direction the Agent is moving, or was last moving
+// if it is stopped.
+func (a *Agent) Heading() geo.Vec {
+	if a.Vel.Len2() > 0 {
+		return a.Vel.Normalized()
+	}
+	return a.heading
+}
+
+// Steer applies force, limited to MaxForce, to the Agent. Like ApplyForce it takes effect
+// on the next Update.
+func (a *Agent) Steer(force geo.Vec) {
+	a.ApplyForce(force.Limited(a.MaxForce))
+}
+
+// Update moves the Agent like Particle.Update but without going faster than MaxSpeed.
+func (a *Agent) Update(dt time.Duration) {
+	a.Particle.Update(dt)
+	over := a.Vel
+	a.Vel.Limit(a.MaxSpeed)
+	a.Pos.Sub(over.Minus(a.Vel).Times(dt.Seconds()))
+	if a.Vel.Len2() > 0 {
+		a.heading = a.Vel.Normalized()
+	}
+}
+
+// steerTowards returns the force that changes the Agent's velocity to desired, limited to
+// MaxForce.
+func (a *Agent) steerTowards(desired geo.Vec) geo.Vec {
+	return desired.Minus(a.Vel).Limited(a.MaxForce)
+}
+
+// Seek returns the force that steers the Agent towards target at full speed.
+func (a *Agent) Seek(target geo.Vec) geo.Vec {
+	return a.steerTowards(target.Minus(a.Pos).WithLen(a.MaxSpeed))
+}
+
+// Flee returns the force that steers the Agent away from threat at full speed. If radius
+// is greater than 0 then threats further away than radius are ignored.
+func (a *Agent) Flee(threat geo.Vec, radius float64) geo.Vec {
+	away := a.Pos.Minus(threat)
+	if radius > 0 && away.Len() > radius {
+		return geo.Vec{}
+	}
+	if away.Len2() == 0 {
+		away = a.Heading()
+	}
+	return a.steerTowards(away.WithLen(a.MaxSpeed))
+}
+
+// Arrive returns the force that steers the Agent towards target like Seek, but slows down
+// once within slowRadius so that it stops at target.
+func (a *Agent) Arrive(target geo.Vec, slowRadius float64) geo.Vec {
+	offset := target.Minus(a.Pos)
+	speed := a.MaxSpeed
+	if d := offset.Len(); d < slowRadius {
+		speed *= d / slowRadius
+	}
+	return a.steerTowards(offset.WithLen(speed))
+}
+
+// predict returns where target will be after the time it would take the Agent to reach
+// where it is now.
+func (a *Agent) predict(target *particle.Particle) geo.Vec {
+	if a.MaxSpeed <= 0 {
+		return target.Pos
+	}
+	t := target.Pos.Dist(a.Pos) / a.MaxSpeed
+	return target.Pos.Plus(target.Vel.Times(t))
+}
+
+// Pursue returns the force that steers the Agent to intercept target by seeking where it
+// is going to be.
+func (a *Agent) Pursue(target *particle.Particle) geo.Vec {
+	return a.Seek(a.predict(target))
+}
+
+// Evade returns the force that steers the Agent away from where threat is going to be. If
+// radius is greater than 0 then threats further away than radius are ignored.
+func (a *Agent) Evade(threat *particle.Particle, radius float64) geo.Vec {
+	if radius > 0 && threat.Pos.Dist(a.Pos) > radius {
+		return geo.Vec{}
+	}
+	return a.Flee(a.predict(threat), 0)
+}
+
+// Wander returns the force that steers the Agent on a smooth random walk. dt is the time
+// since the last call to Wander, which decides how far the wander target moves.
+func (a *Agent) Wander(dt time.Duration) geo.Vec {
+	var r float64
+	if a.Rng != nil {
+		r = a.Rng.Float64()
+	} else {
+		r = rand.Float64()
+	}
+	a.wanderAngle += (r*2 - 1) * a.WanderJitter * dt.Seconds()
+	heading := a.Heading()
+	center := a.Pos.Plus(heading.Times(a.WanderDistance))
+	return a.Seek(center.Plus(heading.Times(a.WanderRadius).Rotated(a.wanderAngle)))
+}
+
+// Blend returns the sum of forces each scaled by the corresponding weight. forces and
+// weights must be the same length.
+func Blend(forces []geo.Vec, weights []float64) geo.Vec {
+	var sum geo.Vec
+	for i, f := range forces {
+		sum.Add(f.Times(weights[i]))
+	}
+	return sum
+}
+
+// Prioritize returns the sum of forces in order until their total strength would exceed
+// MaxForce, at which point the last force is shortened and the rest are ignored. Forces
+// that matter most, like avoiding obstacles, should come first so they are never crowded
+// out.
+func (a *Agent) Prioritize(forces ...geo.Vec) geo.Vec {
+	var sum geo.Vec
+	left := a.MaxForce
+	for _, f := range forces {
+		l := f.Len()
+		if l >= left {
+			return sum.Plus(f.WithLen(left))
+		}
+		sum.Add(f)
+		left -= l
+	}
+	return sum
+}
@@ -0,0 +1,187 @@
+package steering
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+	"github.com/Bredgren/gogame/particle"
+)
+
+const e = 1e-10
+
+const frame = time.Second / 60
+
+func TestBehaviors(t *testing.T) {
+	cases := []struct {
+		vel   geo.Vec
+		force func(a *Agent) geo.Vec
+		want  geo.Vec
+	}{
+		{geo.Vec{}, func(a *Agent) geo.Vec { return a.Seek(geo.Vec{X: 100}) }, geo.Vec{X: 5}},
+		{geo.Vec{X: 8}, func(a *Agent) geo.Vec { return a.Seek(geo.Vec{X: 100}) }, geo.Vec{X: 2}},
+		{geo.Vec{X: 10}, func(a *Agent) geo.Vec { return a.Seek(geo.Vec{X: 100}) }, geo.Vec{}},
+		{geo.Vec{X: 10}, func(a *Agent) geo.Vec { return a.Seek(geo.Vec{X: -100}) }, geo.Vec{X: -5}},
+		{geo.Vec{}, func(a *Agent) geo.Vec { return a.Flee(geo.Vec{X: 3, Y: 4}, 0) }, geo.Vec{X: -3, Y: -4}},
+		{geo.Vec{}, func(a *Agent) geo.Vec { return a.Flee(geo.Vec{X: 3, Y: 4}, 4) }, geo.Vec{}},
+		{geo.Vec{X: 10}, func(a *Agent) geo.Vec { return a.Arrive(geo.Vec{X: 100}, 50) }, geo.Vec{}},
+		{geo.Vec{X: 10}, func(a *Agent) geo.Vec { return a.Arrive(geo.Vec{X: 20}, 50) }, geo.Vec{X: -5}},
+		{geo.Vec{X: 5}, func(a *Agent) geo.Vec { return a.Arrive(geo.Vec{X: 20}, 50) }, geo.Vec{X: -1}},
+		{geo.Vec{}, func(a *Agent) geo.Vec {
+			// It would take 10 seconds to get to where the target is, by which time it has
+			// moved up 100.
+			return a.Pursue(&particle.Particle{Pos: geo.Vec{X: 100}, Vel: geo.Vec{Y: -10}})
+		}, geo.Vec{X: 5 / math.Sqrt2, Y: -5 / math.Sqrt2}},
+		{geo.Vec{}, func(a *Agent) geo.Vec {
+			return a.Evade(&particle.Particle{Pos: geo.Vec{X: 100}, Vel: geo.Vec{Y: -10}}, 0)
+		}, geo.Vec{X: -5 / math.Sqrt2, Y: 5 / math.Sqrt2}},
+		{geo.Vec{}, func(a *Agent) geo.Vec {
+			return a.Evade(&particle.Particle{Pos: geo.Vec{X: 100}, Vel: geo.Vec{Y: -10}}, 99)
+		}, geo.Vec{}},
+	}
+
+	for i, c := range cases {
+		a := NewAgent(geo.Vec{}, 10, 5)
+		a.Vel = c.vel
+		if got := c.force(a); !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	a := NewAgent(geo.Vec{}, 10, 5)
+	a.Vel = geo.Vec{X: 8}
+	a.Steer(geo.Vec{X: 100})
+	a.Update(time.Second)
+	if want := (geo.Vec{X: 10}); a.Vel != want || a.Pos != want {
+		t.Errorf("got %#v moving %#v, want %#v", a.Pos, a.Vel, want)
+	}
+
+	a.Vel = geo.Vec{}
+	if want := (geo.Vec{X: 1}); a.Heading() != want {
+		t.Errorf("got heading %#v, want %#v", a.Heading(), want)
+	}
+}
+
+func TestBlend(t *testing.T) {
+	got := Blend([]geo.Vec{{X: 1}, {Y: 2}, {X: 3}}, []float64{2, 0.5, 0})
+	if want := (geo.Vec{X: 2, Y: 1}); got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	cases := []struct {
+		forces []geo.Vec
+		want   geo.Vec
+	}{
+		{nil, geo.Vec{}},
+		{[]geo.Vec{{X: 3}, {Y: 1}}, geo.Vec{X: 3, Y: 1}},
+		{[]geo.Vec{{X: 3}, {Y: 4}, {X: 1}}, geo.Vec{X: 3, Y: 2}},
+		{[]geo.Vec{{}, {X: -8}, {Y: 4}}, geo.Vec{X: -5}},
+	}
+
+	for i, c := range cases {
+		a := NewAgent(geo.Vec{}, 10, 5)
+		if got := a.Prioritize(c.forces...); !got.Equals(c.want, e) {
+			t.Errorf("case %d: got %#v, want %#v", i, got, c.want)
+		}
+	}
+}
+
+func TestArrive(t *testing.T) {
+	a := NewAgent(geo.Vec{}, 100, 200)
+	target := geo.Vec{X: 300, Y: -100}
+	for i := 0; i < 20*60; i++ {
+		a.Steer(a.Arrive(target, 100))
+		a.Update(frame)
+		if a.Vel.Len() > a.MaxSpeed+e {
+			t.Fatalf("going %v", a.Vel.Len())
+		}
+	}
+	if !a.Pos.Equals(target, 0.1) || a.Vel.Len() > 0.1 {
+		t.Errorf("got %#v moving %#v, want %#v", a.Pos, a.Vel, target)
+	}
+}
+
+func TestWander(t *testing.T) {
+	var paths [2][]geo.Vec
+	for i := range paths {
+		a := NewAgent(geo.Vec{}, 100, 200)
+		a.Rng = geo.NewRngSeed(1)
+		for j := 0; j < 5*60; j++ {
+			a.Steer(a.Wander(frame))
+			a.Update(frame)
+			paths[i] = append(paths[i], a.Pos)
+		}
+		if a.Vel.Len() < 50 {
+			t.Errorf("wandering too slowly at %v", a.Vel.Len())
+		}
+	}
+	for i := range paths[0] {
+		if paths[0][i] != paths[1][i] {
+			t.Fatalf("same seed went different ways")
+		}
+	}
+	// It turns rather than going straight.
+	end := paths[0][len(paths[0])-1]
+	if math.Abs(end.Y) < 1 {
+		t.Errorf("went straight to %#v", end)
+	}
+}
+
+func TestFollowPath(t *testing.T) {
+	path := []geo.Vec{{X: 0, Y: 0}, {X: 200, Y: 0}, {X: 200, Y: 200}, {X: 0, Y: 200}}
+	a := NewAgent(geo.Vec{X: -20, Y: 30}, 100, 500)
+	a.LookAhead = time.Second
+	for i := 0; i < 20*60; i++ {
+		a.Steer(a.FollowPath(path))
+		a.Update(frame)
+		// It cuts and overshoots the corners a bit but otherwise stays on the path.
+		if i > 60 && distToPath(path, a.Pos) > 50 {
+			t.Fatalf("strayed to %#v", a.Pos)
+		}
+	}
+	if want := path[3]; !a.Pos.Equals(want, 1) {
+		t.Errorf("got %#v, want %#v", a.Pos, want)
+	}
+}
+
+func distToPath(path []geo.Vec, v geo.Vec) float64 {
+	d := math.Inf(1)
+	for i := 0; i+1 < len(path); i++ {
+		d = math.Min(d, geo.Segment{A: path[i], B: path[i+1]}.Dist(v))
+	}
+	return d
+}
+
+func TestAvoidObstacles(t *testing.T) {
+	obstacles := []Obstacle{
+		geo.Circle{X: 100, Y: 0, R: 20},
+		geo.Rect{X: 180, Y: -30, W: 20, H: 60},
+		geo.Polygon{{X: 260, Y: -10}, {X: 280, Y: -30}, {X: 300, Y: 10}},
+	}
+	target := geo.Vec{X: 400}
+	a := NewAgent(geo.Vec{}, 100, 300)
+	a.Radius = 5
+	a.LookAhead = time.Second
+	for i := 0; i < 10*60; i++ {
+		a.Steer(a.Prioritize(a.AvoidObstacles(obstacles...), a.Arrive(target, 50)))
+		a.Update(frame)
+		for j, o := range obstacles {
+			if d := o.ClosestPoint(a.Pos).Dist(a.Pos); d < a.Radius {
+				t.Fatalf("hit obstacle %d at %#v", j, a.Pos)
+			}
+		}
+	}
+	if !a.Pos.Equals(target, 1) {
+		t.Errorf("got %#v, want %#v", a.Pos, target)
+	}
+
+	// Nothing in the way.
+	a = NewAgent(geo.Vec{}, 100, 300)
+	a.Vel = geo.Vec{Y: 100}
+	if got := a.AvoidObstacles(obstacles...); got != (geo.Vec{}) {
+		t.Errorf("got %#v", got)
+	}
+}
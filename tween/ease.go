@@ -0,0 +1,202 @@
+package tween
+
+import "math"
+
+// EaseFunc maps linear progress t in [0, 1] to eased progress. It should return 0 for 0
+// and 1 for 1 but may go outside that range in between, as Back and Elastic do.
+type EaseFunc func(t float64) float64
+
+// OutOf returns the ease-out version of an ease-in function, that is in reverse.
+func OutOf(in EaseFunc) EaseFunc {
+	return func(t float64) float64 {
+		return 1 - in(1-t)
+	}
+}
+
+// InOutOf returns a function that eases in for the first half using in and out for the
+// second half.
+func InOutOf(in EaseFunc) EaseFunc {
+	return func(t float64) float64 {
+		if t < 0.5 {
+			return in(2*t) / 2
+		}
+		return 1 - in(2-2*t)/2
+	}
+}
+
+// Linear is no easing.
+func Linear(t float64) float64 {
+	return t
+}
+
+// QuadIn accelerates from zero velocity.
+func QuadIn(t float64) float64 {
+	return t * t
+}
+
+// QuadOut decelerates to zero velocity.
+func QuadOut(t float64) float64 {
+	return OutOf(QuadIn)(t)
+}
+
+// QuadInOut accelerates until halfway then decelerates.
+func QuadInOut(t float64) float64 {
+	return InOutOf(QuadIn)(t)
+}
+
+// CubicIn accelerates from zero velocity.
+func CubicIn(t float64) float64 {
+	return t * t * t
+}
+
+// CubicOut decelerates to zero velocity.
+func CubicOut(t float64) float64 {
+	return OutOf(CubicIn)(t)
+}
+
+// CubicInOut accelerates until halfway then decelerates.
+func CubicInOut(t float64) float64 {
+	return InOutOf(CubicIn)(t)
+}
+
+// QuartIn accelerates from zero velocity.
+func QuartIn(t float64) float64 {
+	return t * t * t * t
+}
+
+// QuartOut decelerates to zero velocity.
+func QuartOut(t float64) float64 {
+	return OutOf(QuartIn)(t)
+}
+
+// QuartInOut accelerates until halfway then decelerates.
+func QuartInOut(t float64) float64 {
+	return InOutOf(QuartIn)(t)
+}
+
+// QuintIn accelerates from zero velocity.
+func QuintIn(t float64) float64 {
+	return t * t * t * t * t
+}
+
+// QuintOut decelerates to zero velocity.
+func QuintOut(t float64) float64 {
+	return OutOf(QuintIn)(t)
+}
+
+// QuintInOut accelerates until halfway then decelerates.
+func QuintInOut(t float64) float64 {
+	return InOutOf(QuintIn)(t)
+}
+
+// SineIn accelerates following a sine curve.
+func SineIn(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// SineOut decelerates following a sine curve.
+func SineOut(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// SineInOut accelerates until halfway then decelerates following a sine curve.
+func SineInOut(t float64) float64 {
+	return (1 - math.Cos(t*math.Pi)) / 2
+}
+
+// ExpoIn accelerates exponentially.
+func ExpoIn(t float64) float64 {
+	if t == 0 {
+		return 0
+	}
+	return math.Pow(2, 10*(t-1))
+}
+
+// ExpoOut decelerates exponentially.
+func ExpoOut(t float64) float64 {
+	return OutOf(ExpoIn)(t)
+}
+
+// ExpoInOut accelerates exponentially until halfway then decelerates.
+func ExpoInOut(t float64) float64 {
+	return InOutOf(ExpoIn)(t)
+}
+
+// CircIn accelerates following a quarter circle.
+func CircIn(t float64) float64 {
+	return 1 - math.Sqrt(1-t*t)
+}
+
+// CircOut decelerates following a quarter circle.
+func CircOut(t float64) float64 {
+	return OutOf(CircIn)(t)
+}
+
+// CircInOut accelerates until halfway then decelerates following a quarter circle.
+func CircInOut(t float64) float64 {
+	return InOutOf(CircIn)(t)
+}
+
+// backOvershoot is the standard amount Back pulls back by, about 10%.
+const backOvershoot = 1.70158
+
+// BackIn pulls back slightly before accelerating.
+func BackIn(t float64) float64 {
+	return t * t * ((backOvershoot+1)*t - backOvershoot)
+}
+
+// BackOut overshoots the end slightly before settling.
+func BackOut(t float64) float64 {
+	return OutOf(BackIn)(t)
+}
+
+// BackInOut pulls back at the start and overshoots the end.
+func BackInOut(t float64) float64 {
+	return InOutOf(BackIn)(t)
+}
+
+// ElasticIn oscillates with growing amplitude like a spring being pulled.
+func ElasticIn(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*(t-1)) * math.Sin((t-1.075)*2*math.Pi/0.3)
+}
+
+// ElasticOut overshoots and oscillates like a released spring.
+func ElasticOut(t float64) float64 {
+	return OutOf(ElasticIn)(t)
+}
+
+// ElasticInOut oscillates at both ends.
+func ElasticInOut(t float64) float64 {
+	return InOutOf(ElasticIn)(t)
+}
+
+// BounceOut bounces against the end like a dropped ball.
+func BounceOut(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// BounceIn bounces away from the start.
+func BounceIn(t float64) float64 {
+	return OutOf(BounceOut)(t)
+}
+
+// BounceInOut bounces at both ends.
+func BounceInOut(t float64) float64 {
+	return InOutOf(BounceIn)(t)
+}
@@ -0,0 +1,333 @@
+// Package tween animates values over time using easing functions. A Tween tracks eased
+// progress over a duration while Num, Vec, Rect and Color turn that progress into values.
+// Tweens can be chained with Sequence and run together with Group, and anything that
+// animates is a Player so they nest freely.
+//
+// Nothing advances on its own, call Update each frame with the time elapsed:
+//
+//	slide := tween.NewVec(offscreen, onscreen, 500*time.Millisecond, tween.BackOut)
+//	...
+//	slide.Update(dt)
+//	panel.X, panel.Y = slide.Value().X, slide.Value().Y
+package tween
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Player is something that plays out over time.
+type Player interface {
+	// Update advances by dt. If it finishes then done is true and left is the part of dt
+	// that was not needed. Updating after it is done does nothing and returns all of dt.
+	Update(dt time.Duration) (left time.Duration, done bool)
+	// Reset returns to the beginning.
+	Reset()
+}
+
+// Tween tracks eased progress from 0 to 1 over Duration. A Tween by itself, such as one
+// made with Delay, is useful as a pause in a Sequence.
+type Tween struct {
+	// Duration is the time it takes to go from 0 to 1 once.
+	Duration time.Duration
+	// Ease is the easing function to use. Linear is used if it is nil.
+	Ease EaseFunc
+	// Delay is the amount of time to wait before starting. It only happens once, not on
+	// every repeat.
+	Delay time.Duration
+	// Repeat is the number of extra times to play after the first. Negative values repeat
+	// forever, in which case the Tween is never done.
+	Repeat int
+	// Yoyo makes every other repeat play in reverse.
+	Yoyo bool
+	// OnComplete is called, if not nil, when the Tween finishes.
+	OnComplete func()
+	elapsed    time.Duration
+	done       bool
+}
+
+var _ Player = (*Tween)(nil)
+
+// New creates a Tween with the given duration and easing function.
+func New(d time.Duration, ease EaseFunc) *Tween {
+	return &Tween{Duration: d, Ease: ease}
+}
+
+// Delay creates a Tween that does nothing but take time d.
+func Delay(d time.Duration) *Tween {
+	return &Tween{Duration: d}
+}
+
+// total returns the full length including delay and repeats, or -1 if it repeats forever.
+func (t *Tween) total() time.Duration {
+	if t.Repeat < 0 {
+		return -1
+	}
+	return t.Delay + t.Duration*time.Duration(t.Repeat+1)
+}
+
+// Update advances the Tween by dt.
+func (t *Tween) Update(dt time.Duration) (left time.Duration, done bool) {
+	if t.done {
+		return dt, true
+	}
+	t.elapsed += dt
+	if total := t.total(); total >= 0 && t.elapsed >= total {
+		left = t.elapsed - total
+		t.elapsed = total
+		t.done = true
+		if t.OnComplete != nil {
+			t.OnComplete()
+		}
+		return left, true
+	}
+	return 0, false
+}
+
+// Reset returns the Tween to the beginning, including its Delay.
+func (t *Tween) Reset() {
+	t.elapsed = 0
+	t.done = false
+}
+
+// Done returns true if the Tween has finished.
+func (t *Tween) Done() bool {
+	return t.done
+}
+
+// Elapsed returns the amount of time played, including the Delay.
+func (t *Tween) Elapsed() time.Duration {
+	return t.elapsed
+}
+
+// Linear returns the current progress without easing, in the range [0, 1].
+func (t *Tween) Linear() float64 {
+	e := t.elapsed - t.Delay
+	if e < 0 {
+		return 0
+	}
+	if t.Duration <= 0 {
+		return 1
+	}
+	cycle := e / t.Duration
+	x := float64(e%t.Duration) / float64(t.Duration)
+	if t.done {
+		cycle, x = time.Duration(t.Repeat), 1
+	}
+	if t.Yoyo && cycle%2 == 1 {
+		x = 1 - x
+	}
+	return x
+}
+
+// Progress returns the current eased progress. It is 0 at the start and 1 at the end,
+// though it may go outside of [0, 1] in between depending on the easing function.
+func (t *Tween) Progress() float64 {
+	if t.Ease == nil {
+		return t.Linear()
+	}
+	return t.Ease(t.Linear())
+}
+
+// Num tweens between two numbers.
+type Num struct {
+	Tween
+	From, To float64
+}
+
+// NewNum creates a Num that goes from from to to over d.
+func NewNum(from, to float64, d time.Duration, ease EaseFunc) *Num {
+	return &Num{Tween: Tween{Duration: d, Ease: ease}, From: from, To: to}
+}
+
+// Value returns the current value.
+func (n *Num) Value() float64 {
+	return n.From + (n.To-n.From)*n.Progress()
+}
+
+// NumGen returns a geo.NumGen that always returns the current value. This can be used to
+// animate the parameters of things like particle.System.
+func (n *Num) NumGen() geo.NumGen {
+	return n.Value
+}
+
+// Vec tweens between two vectors.
+type Vec struct {
+	Tween
+	From, To geo.Vec
+}
+
+// NewVec creates a Vec that goes from from to to over d.
+func NewVec(from, to geo.Vec, d time.Duration, ease EaseFunc) *Vec {
+	return &Vec{Tween: Tween{Duration: d, Ease: ease}, From: from, To: to}
+}
+
+// Value returns the current value.
+func (v *Vec) Value() geo.Vec {
+	return v.From.Plus(v.To.Minus(v.From).Times(v.Progress()))
+}
+
+// VecGen returns a geo.VecGen that always returns the current value. This can be used to
+// animate the parameters of things like particle.System.
+func (v *Vec) VecGen() geo.VecGen {
+	return v.Value
+}
+
+// Rect tweens between two rectangles.
+type Rect struct {
+	Tween
+	From, To geo.Rect
+}
+
+// NewRect creates a Rect that goes from from to to over d.
+func NewRect(from, to geo.Rect, d time.Duration, ease EaseFunc) *Rect {
+	return &Rect{Tween: Tween{Duration: d, Ease: ease}, From: from, To: to}
+}
+
+// Value returns the current value.
+func (r *Rect) Value() geo.Rect {
+	p := r.Progress()
+	return geo.Rect{
+		X: r.From.X + (r.To.X-r.From.X)*p,
+		Y: r.From.Y + (r.To.Y-r.From.Y)*p,
+		W: r.From.W + (r.To.W-r.From.W)*p,
+		H: r.From.H + (r.To.H-r.From.H)*p,
+	}
+}
+
+// Color tweens between two colors. Interpolation is done on the alpha-premultiplied
+// components.
+type Color struct {
+	Tween
+	From, To color.Color
+}
+
+// NewColor creates a Color that goes from from to to over d.
+func NewColor(from, to color.Color, d time.Duration, ease EaseFunc) *Color {
+	return &Color{Tween: Tween{Duration: d, Ease: ease}, From: from, To: to}
+}
+
+// Value returns the current value.
+func (c *Color) Value() color.Color {
+	p := c.Progress()
+	r1, g1, b1, a1 := c.From.RGBA()
+	r2, g2, b2, a2 := c.To.RGBA()
+	lerp := func(v1, v2 uint32) uint16 {
+		v := float64(v1) + (float64(v2)-float64(v1))*p
+		return uint16(math.Max(0, math.Min(0xffff, math.Round(v))))
+	}
+	a := lerp(a1, a2)
+	// Keep it a valid premultiplied color if the easing overshoots.
+	clampA := func(v uint16) uint16 {
+		if v > a {
+			return a
+		}
+		return v
+	}
+	return color.RGBA64{R: clampA(lerp(r1, r2)), G: clampA(lerp(g1, g2)), B: clampA(lerp(b1, b2)), A: a}
+}
+
+// Sequence plays Players one after another.
+type Sequence struct {
+	Players []Player
+	// OnComplete is called, if not nil, when the last Player finishes.
+	OnComplete func()
+	current    int
+}
+
+var _ Player = (*Sequence)(nil)
+
+// NewSequence creates a Sequence of the given Players.
+func NewSequence(players ...Player) *Sequence {
+	return &Sequence{Players: players}
+}
+
+// Update advances the current Player by dt, moving on to the next with any time left over.
+func (s *Sequence) Update(dt time.Duration) (left time.Duration, done bool) {
+	if s.current >= len(s.Players) {
+		return dt, true
+	}
+	for s.current < len(s.Players) {
+		left, done := s.Players[s.current].Update(dt)
+		if !done {
+			return 0, false
+		}
+		dt = left
+		s.current++
+	}
+	if s.OnComplete != nil {
+		s.OnComplete()
+	}
+	return dt, true
+}
+
+// Reset resets the Sequence and every Player in it.
+func (s *Sequence) Reset() {
+	s.current = 0
+	for _, p := range s.Players {
+		p.Reset()
+	}
+}
+
+// Group plays Players at the same time. It is done when all of them are.
+type Group struct {
+	Players []Player
+	// OnComplete is called, if not nil, when the last Player finishes.
+	OnComplete func()
+	done       []bool
+	finished   bool
+}
+
+var _ Player = (*Group)(nil)
+
+// NewGroup creates a Group of the given Players.
+func NewGroup(players ...Player) *Group {
+	return &Group{Players: players}
+}
+
+// Update advances every unfinished Player by dt.
+func (g *Group) Update(dt time.Duration) (left time.Duration, done bool) {
+	if g.finished {
+		return dt, true
+	}
+	if len(g.done) != len(g.Players) {
+		g.done = make([]bool, len(g.Players))
+	}
+	left = dt
+	all := true
+	for i, p := range g.Players {
+		if g.done[i] {
+			continue
+		}
+		l, done := p.Update(dt)
+		if !done {
+			all = false
+			continue
+		}
+		g.done[i] = true
+		// The Group finishes when the slowest Player does.
+		if l < left {
+			left = l
+		}
+	}
+	if !all {
+		return 0, false
+	}
+	g.finished = true
+	if g.OnComplete != nil {
+		g.OnComplete()
+	}
+	return left, true
+}
+
+// Reset resets the Group and every Player in it.
+func (g *Group) Reset() {
+	g.done = nil
+	g.finished = false
+	for _, p := range g.Players {
+		p.Reset()
+	}
+}
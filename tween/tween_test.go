@@ -0,0 +1,196 @@
+package tween
+
+import (
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const e = 1e-9
+
+func TestEaseEndpoints(t *testing.T) {
+	eases := map[string]EaseFunc{
+		"Linear": Linear,
+		"QuadIn": QuadIn, "QuadOut": QuadOut, "QuadInOut": QuadInOut,
+		"CubicIn": CubicIn, "CubicOut": CubicOut, "CubicInOut": CubicInOut,
+		"QuartIn": QuartIn, "QuartOut": QuartOut, "QuartInOut": QuartInOut,
+		"QuintIn": QuintIn, "QuintOut": QuintOut, "QuintInOut": QuintInOut,
+		"SineIn": SineIn, "SineOut": SineOut, "SineInOut": SineInOut,
+		"ExpoIn": ExpoIn, "ExpoOut": ExpoOut, "ExpoInOut": ExpoInOut,
+		"CircIn": CircIn, "CircOut": CircOut, "CircInOut": CircInOut,
+		"BackIn": BackIn, "BackOut": BackOut, "BackInOut": BackInOut,
+		"ElasticIn": ElasticIn, "ElasticOut": ElasticOut, "ElasticInOut": ElasticInOut,
+		"BounceIn": BounceIn, "BounceOut": BounceOut, "BounceInOut": BounceInOut,
+	}
+
+	for name, ease := range eases {
+		if got := ease(0); math.Abs(got) > 1e-3 {
+			t.Errorf("%s(0) = %v, want 0", name, got)
+		}
+		if got := ease(1); math.Abs(got-1) > 1e-3 {
+			t.Errorf("%s(1) = %v, want 1", name, got)
+		}
+	}
+
+	if got := QuadInOut(0.5); math.Abs(got-0.5) > e {
+		t.Errorf("QuadInOut(0.5) = %v, want 0.5", got)
+	}
+	if got := QuadOut(0.5); math.Abs(got-0.75) > e {
+		t.Errorf("QuadOut(0.5) = %v, want 0.75", got)
+	}
+	if BackIn(0.2) >= 0 {
+		t.Errorf("BackIn should pull back below 0")
+	}
+}
+
+func TestTweenProgress(t *testing.T) {
+	cases := []struct {
+		tween *Tween
+		steps []time.Duration
+		want  []float64
+	}{
+		{
+			&Tween{Duration: time.Second},
+			[]time.Duration{0, 250 * time.Millisecond, 250 * time.Millisecond, time.Second},
+			[]float64{0, 0.25, 0.5, 1},
+		},
+		{
+			&Tween{Duration: time.Second, Delay: time.Second},
+			[]time.Duration{500 * time.Millisecond, 1000 * time.Millisecond, time.Second},
+			[]float64{0, 0.5, 1},
+		},
+		{
+			&Tween{Duration: time.Second, Ease: QuadIn},
+			[]time.Duration{500 * time.Millisecond},
+			[]float64{0.25},
+		},
+		{
+			&Tween{Duration: time.Second, Repeat: 1, Yoyo: true},
+			[]time.Duration{750 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond, time.Second},
+			[]float64{0.75, 0.75, 0.25, 0},
+		},
+		{
+			&Tween{Duration: time.Second, Repeat: 2},
+			[]time.Duration{1250 * time.Millisecond, time.Second, time.Hour},
+			[]float64{0.25, 0.25, 1},
+		},
+		{
+			&Tween{Duration: time.Second, Repeat: -1, Yoyo: true},
+			[]time.Duration{time.Hour + 250*time.Millisecond, time.Second},
+			[]float64{0.25, 0.75},
+		},
+	}
+
+	for i, c := range cases {
+		for j, dt := range c.steps {
+			c.tween.Update(dt)
+			if got := c.tween.Progress(); math.Abs(got-c.want[j]) > e {
+				t.Errorf("case %d step %d: got %v, want %v", i, j, got, c.want[j])
+			}
+		}
+	}
+}
+
+func TestTweenComplete(t *testing.T) {
+	completed := 0
+	tw := &Tween{Duration: time.Second, OnComplete: func() { completed++ }}
+	if left, done := tw.Update(600 * time.Millisecond); done || left != 0 {
+		t.Errorf("got %v %v, want 0 false", left, done)
+	}
+	if left, done := tw.Update(600 * time.Millisecond); !done || left != 200*time.Millisecond {
+		t.Errorf("got %v %v, want 200ms true", left, done)
+	}
+	if left, done := tw.Update(time.Second); !done || left != time.Second {
+		t.Errorf("after done got %v %v, want 1s true", left, done)
+	}
+	if completed != 1 {
+		t.Errorf("OnComplete called %d times, want 1", completed)
+	}
+	tw.Reset()
+	if tw.Done() || tw.Progress() != 0 {
+		t.Errorf("Reset didn't reset")
+	}
+}
+
+func TestValues(t *testing.T) {
+	n := NewNum(10, 20, time.Second, nil)
+	v := NewVec(geo.Vec{}, geo.Vec{X: 10, Y: -10}, time.Second, nil)
+	r := NewRect(geo.Rect{}, geo.Rect{X: 10, Y: 10, W: 2, H: 4}, time.Second, nil)
+	c := NewColor(color.Black, color.White, time.Second, nil)
+	NewGroup(n, v, r, c).Update(500 * time.Millisecond)
+
+	if got := n.Value(); got != 15 {
+		t.Errorf("Num: got %v, want 15", got)
+	}
+	if got := n.NumGen()(); got != 15 {
+		t.Errorf("NumGen: got %v, want 15", got)
+	}
+	if got := v.VecGen()(); got != (geo.Vec{X: 5, Y: -5}) {
+		t.Errorf("Vec: got %#v", got)
+	}
+	if got := r.Value(); got != (geo.Rect{X: 5, Y: 5, W: 1, H: 2}) {
+		t.Errorf("Rect: got %#v", got)
+	}
+	if got := color.GrayModel.Convert(c.Value()).(color.Gray); got.Y != 128 {
+		t.Errorf("Color: got %#v", got)
+	}
+}
+
+func TestColorOvershoot(t *testing.T) {
+	c := NewColor(color.RGBA{A: 255}, color.RGBA{R: 255, A: 255}, time.Second, BackOut)
+	c.Update(700 * time.Millisecond)
+	r, _, _, a := c.Value().RGBA()
+	if r > a {
+		t.Errorf("invalid premultiplied color r=%d a=%d", r, a)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	order := []string{}
+	a := NewNum(0, 1, time.Second, nil)
+	a.OnComplete = func() { order = append(order, "a") }
+	b := NewNum(0, 1, time.Second, nil)
+	b.OnComplete = func() { order = append(order, "b") }
+	s := NewSequence(a, Delay(time.Second), b)
+	s.OnComplete = func() { order = append(order, "s") }
+
+	s.Update(1500 * time.Millisecond)
+	if a.Value() != 1 || b.Value() != 0 {
+		t.Errorf("got a=%v b=%v, want 1 0", a.Value(), b.Value())
+	}
+	s.Update(time.Second)
+	if b.Value() != 0.5 {
+		t.Errorf("got b=%v, want 0.5", b.Value())
+	}
+	left, done := s.Update(time.Second)
+	if !done || left != 500*time.Millisecond {
+		t.Errorf("got %v %v, want 500ms true", left, done)
+	}
+	if len(order) != 3 || order[0] != "a" || order[1] != "b" || order[2] != "s" {
+		t.Errorf("got order %v", order)
+	}
+
+	s.Reset()
+	if a.Done() || a.Value() != 0 {
+		t.Errorf("Reset didn't reset children")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	completed := false
+	a := NewNum(0, 1, time.Second, nil)
+	b := NewNum(0, 1, 2*time.Second, nil)
+	g := NewGroup(a, b)
+	g.OnComplete = func() { completed = true }
+
+	if _, done := g.Update(1500 * time.Millisecond); done || !a.Done() {
+		t.Errorf("expected only a to be done")
+	}
+	left, done := g.Update(time.Second)
+	if !done || !completed || left != 500*time.Millisecond {
+		t.Errorf("got %v %v %v, want 500ms true true", left, done, completed)
+	}
+}
@@ -0,0 +1,96 @@
+package verlet
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Object is a group of Points and the Constraints between them, such as a rope. Use World.Add
+// to simulate it.
+type Object struct {
+	Points      []*Point
+	Constraints []Constraint
+}
+
+// SetTear makes every Distance constraint in the Object tear when stretched to ratio times
+// its Length. A ratio of 0 stops them from tearing.
+func (o *Object) SetTear(ratio float64) {
+	for _, c := range o.Constraints {
+		if d, ok := c.(*Distance); ok {
+			d.TearLength = d.Length * ratio
+		}
+	}
+}
+
+// Rope creates a rope from one point to another made of the given number of segments, which
+// must be at least 1. The first Point is pinned in place by the last Constraint.
+func Rope(from, to geo.Vec, segments int, stiffness float64) *Object {
+	o := &Object{}
+	for i := 0; i <= segments; i++ {
+		p := NewPoint(from.Plus(to.Minus(from).Times(float64(i) / float64(segments))))
+		if i > 0 {
+			o.Constraints = append(o.Constraints, NewDistance(o.Points[i-1], p, stiffness))
+		}
+		o.Points = append(o.Points, p)
+	}
+	o.Constraints = append(o.Constraints, NewPin(o.Points[0]))
+	return o
+}
+
+// Cloth creates a grid of cols by rows Points covering r, each joined to its neighbors
+// above, below, left and right. The Points are in rows from the top left. The top row is
+// pinned in place by the last cols Constraints.
+func Cloth(r geo.Rect, cols, rows int, stiffness float64) *Object {
+	o := &Object{}
+	at := func(col, row int) *Point {
+		return o.Points[row*cols+col]
+	}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			pos := geo.Vec{X: r.X, Y: r.Y}
+			if cols > 1 {
+				pos.X += r.W * float64(col) / float64(cols-1)
+			}
+			if rows > 1 {
+				pos.Y += r.H * float64(row) / float64(rows-1)
+			}
+			p := NewPoint(pos)
+			o.Points = append(o.Points, p)
+			if col > 0 {
+				o.Constraints = append(o.Constraints, NewDistance(at(col-1, row), p, stiffness))
+			}
+			if row > 0 {
+				o.Constraints = append(o.Constraints, NewDistance(at(col, row-1), p, stiffness))
+			}
+		}
+	}
+	for col := 0; col < cols; col++ {
+		o.Constraints = append(o.Constraints, NewPin(at(col, 0)))
+	}
+	return o
+}
+
+// Ring creates a soft body of n Points in a circle joined to their neighbors by stiff
+// Distance constraints. The last Point is at the center and is joined to each of the others
+// by Distance constraints with the given stiffness, so lower values make it more jelly-like.
+func Ring(center geo.Vec, radius float64, n int, stiffness float64) *Object {
+	o := &Object{}
+	mid := NewPoint(center)
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		p := NewPoint(center.Plus(geo.Vec{X: radius}.Rotated(a)))
+		if i > 0 {
+			o.Constraints = append(o.Constraints, NewDistance(o.Points[i-1], p, 1))
+		}
+		o.Points = append(o.Points, p)
+	}
+	if n > 1 {
+		o.Constraints = append(o.Constraints, NewDistance(o.Points[n-1], o.Points[0], 1))
+	}
+	for _, p := range o.Points {
+		o.Constraints = append(o.Constraints, NewDistance(mid, p, stiffness))
+	}
+	o.Points = append(o.Points, mid)
+	return o
+}
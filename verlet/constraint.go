@@ -0,0 +1,136 @@
+package verlet
+
+import (
+	"math"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Constraint limits how points can move relative to each other or the world.
+type Constraint interface {
+	// Solve moves the constrained points towards satisfying the constraint. It returns true
+	// if the constraint has torn, in which case it is removed from the World.
+	Solve() (torn bool)
+}
+
+var (
+	_ Constraint = (*Distance)(nil)
+	_ Constraint = (*Angle)(nil)
+	_ Constraint = (*Pin)(nil)
+	_ Constraint = (*Bounds)(nil)
+)
+
+// Distance keeps two points a set distance apart, like a stick or a spring.
+type Distance struct {
+	A, B *Point
+	// Length is the distance the points are kept at.
+	Length float64
+	// Stiffness is the fraction of the error corrected on each Solve, between 0 and 1.
+	// Lower values stretch like springs.
+	Stiffness float64
+	// TearLength, if greater than 0, is the distance at which the constraint tears.
+	TearLength float64
+}
+
+// NewDistance creates a Distance that keeps a and b at their current distance.
+func NewDistance(a, b *Point, stiffness float64) *Distance {
+	return &Distance{A: a, B: b, Length: a.Pos.Dist(b.Pos), Stiffness: stiffness}
+}
+
+// Solve moves the points towards Length apart, with the lighter one moving further. It
+// tears if they are further apart than TearLength.
+func (d *Distance) Solve() bool {
+	delta := d.B.Pos.Minus(d.A.Pos)
+	dist := delta.Len()
+	if d.TearLength > 0 && dist > d.TearLength {
+		return true
+	}
+	invA, invB := d.A.invMass(), d.B.invMass()
+	if dist == 0 || invA+invB == 0 {
+		return false
+	}
+	move := delta.Times(d.Stiffness * (dist - d.Length) / dist / (invA + invB))
+	d.A.Pos.Add(move.Times(invA))
+	d.B.Pos.Sub(move.Times(invB))
+	return false
+}
+
+// Angle keeps the angle at the point B between A and C, like a joint. The angle is measured
+// from A to C around B, counterclockwise in screen coordinates.
+type Angle struct {
+	A, B, C *Point
+	// Angle is the angle kept at B in radians.
+	Angle float64
+	// Stiffness is the fraction of the error corrected on each Solve, between 0 and 1.
+	Stiffness float64
+}
+
+// NewAngle creates an Angle that keeps the current angle between a, b and c.
+func NewAngle(a, b, c *Point, stiffness float64) *Angle {
+	return &Angle{A: a, B: b, C: c, Angle: angle(a.Pos, b.Pos, c.Pos), Stiffness: stiffness}
+}
+
+// angle returns the radians from a to c around b, counterclockwise in screen coordinates.
+func angle(a, b, c geo.Vec) float64 {
+	return c.Minus(b).AngleFrom(a.Minus(b))
+}
+
+// Solve rotates A and C around B towards the Angle, with the lighter one turning further.
+// It never tears.
+func (a *Angle) Solve() bool {
+	diff := a.Angle - angle(a.A.Pos, a.B.Pos, a.C.Pos)
+	diff = math.Mod(diff+3*math.Pi, 2*math.Pi) - math.Pi
+	invA, invC := a.A.invMass(), a.C.invMass()
+	if invA+invC == 0 {
+		return false
+	}
+	turn := diff * a.Stiffness / (invA + invC)
+	a.A.Pos = a.A.Pos.Minus(a.B.Pos).Rotated(-turn * invA).Plus(a.B.Pos)
+	a.C.Pos = a.C.Pos.Minus(a.B.Pos).Rotated(turn * invC).Plus(a.B.Pos)
+	return false
+}
+
+// Pin holds a Point at a position, which can be changed to drag the Point around.
+type Pin struct {
+	Point *Point
+	Pos   geo.Vec
+}
+
+// NewPin creates a Pin that holds p at its current position.
+func NewPin(p *Point) *Pin {
+	return &Pin{Point: p, Pos: p.Pos}
+}
+
+// Solve moves the Point to Pos. It never tears.
+func (p *Pin) Solve() bool {
+	p.Point.Pos = p.Pos
+	return false
+}
+
+// Bounds keeps points inside a Rect, like the walls of a box.
+type Bounds struct {
+	Points []*Point
+	Rect   geo.Rect
+	// Bounce is the fraction of a Point's speed into a wall it keeps after hitting it,
+	// between 0 and 1.
+	Bounce float64
+}
+
+// Solve moves any Points that are outside of Rect to its edge. It never tears.
+func (b *Bounds) Solve() bool {
+	for _, p := range b.Points {
+		if p.Mass <= 0 {
+			continue
+		}
+		vel := p.Pos.Minus(p.Prev)
+		if x := math.Max(b.Rect.Left(), math.Min(p.Pos.X, b.Rect.Right())); x != p.Pos.X {
+			p.Pos.X = x
+			p.Prev.X = x + vel.X*b.Bounce
+		}
+		if y := math.Max(b.Rect.Top(), math.Min(p.Pos.Y, b.Rect.Bottom())); y != p.Pos.Y {
+			p.Pos.Y = y
+			p.Prev.Y = y + vel.Y*b.Bounce
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+// Package verlet simulates points joined by constraints using position based dynamics,
+// which suits ropes, chains, cloth and jelly-like soft bodies. Unlike particle.Particle,
+// a Point's velocity isn't stored but comes from how far it moved in the last Update, so
+// constraints can simply move points around and the velocities follow.
+//
+// A rope hanging from the mouse might look like:
+//
+//	world := verlet.NewWorld(geo.Vec{Y: 500})
+//	rope := verlet.Rope(geo.Vec{X: 100, Y: 100}, geo.Vec{X: 300, Y: 100}, 20, 1)
+//	world.Add(rope)
+//	pin := rope.Constraints[len(rope.Constraints)-1].(*verlet.Pin)
+//	...
+//	pin.Pos = ggweb.MousePos()
+//	world.Update(dt)
+//	// Draw a line through the rope's Points.
+package verlet
+
+import (
+	"math"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+// Point is a particle whose velocity is the distance it moved in the last Update. Use
+// NewPoint to create one.
+type Point struct {
+	// Pos is the position of the Point.
+	Pos geo.Vec
+	// Prev is the position of the Point before the last Update. Moving both Pos and Prev
+	// teleports the Point without changing its velocity.
+	Prev geo.Vec
+	// Mass decides how the Point shares movement with other points in a constraint. A Mass
+	// of 0 makes it fixed in place, so it ignores gravity, forces and constraints.
+	Mass float64
+
+	accel  geo.Vec
+	lastDt time.Duration
+}
+
+// NewPoint creates a still Point at pos with a Mass of 1.
+func NewPoint(pos geo.Vec) *Point {
+	return &Point{Pos: pos, Prev: pos, Mass: 1}
+}
+
+// Update moves the Point by its velocity and any forces applied since the last call to
+// Update. dt is the amount of time to advance, which can differ between calls. A dt of 0 or
+// less does nothing, so pausing doesn't lose the velocity.
+func (p *Point) Update(dt time.Duration) {
+	if dt <= 0 {
+		return
+	}
+	// Scaling the movement by the ratio of time steps keeps the velocity the same when dt
+	// changes.
+	ratio := 1.0
+	if p.lastDt > 0 {
+		ratio = dt.Seconds() / p.lastDt.Seconds()
+	}
+	next := p.Pos.Plus(p.Pos.Minus(p.Prev).Times(ratio)).Plus(p.accel.Times(dt.Seconds() * dt.Seconds()))
+	p.Prev, p.Pos = p.Pos, next
+	p.accel.Mul(0)
+	p.lastDt = dt
+}
+
+// ApplyForce applies the given force to the Point taking its Mass into account. It will not
+// affect the Point until Update is called. Forces accumulate and do not persist between
+// calls to Update.
+func (p *Point) ApplyForce(force geo.Vec) {
+	if p.Mass != 0 {
+		p.accel.Add(force.DividedBy(p.Mass))
+	}
+}
+
+// Vel returns the velocity of the Point in units per second, based on the last Update.
+func (p *Point) Vel() geo.Vec {
+	if p.lastDt <= 0 {
+		return geo.Vec{}
+	}
+	return p.Pos.Minus(p.Prev).DividedBy(p.lastDt.Seconds())
+}
+
+func (p *Point) invMass() float64 {
+	if p.Mass <= 0 {
+		return 0
+	}
+	return 1 / p.Mass
+}
+
+// World holds points and the constraints between them. Use NewWorld to create one.
+type World struct {
+	// Gravity is the acceleration applied to every Point, in units per second per second.
+	Gravity geo.Vec
+	// Damping is the fraction of a Point's velocity lost each second, between 0 and 1.
+	Damping float64
+	// Iterations is how many times per Update the Constraints are solved. More iterations
+	// make constraints stiffer but take longer.
+	Iterations int
+
+	// Points are moved by Update. Points with a Mass of 0 are left alone.
+	Points []*Point
+	// Constraints are solved in order by Update, so constraints that must be met exactly,
+	// like Pin, should come last.
+	Constraints []Constraint
+
+	// OnTear, if not nil, is called for each Constraint that tears during Update, after it
+	// has been removed from Constraints.
+	OnTear func(c Constraint)
+}
+
+// NewWorld creates an empty World with the given Gravity.
+func NewWorld(gravity geo.Vec) *World {
+	return &World{
+		Gravity:    gravity,
+		Iterations: 8,
+	}
+}
+
+// Add adds the Object's Points and Constraints to the World.
+func (w *World) Add(o *Object) {
+	w.Points = append(w.Points, o.Points...)
+	w.Constraints = append(w.Constraints, o.Constraints...)
+}
+
+// Update moves the Points by dt then solves the Constraints, removing any that tear. If dt
+// is 0 or less then the Points only move to satisfy the Constraints.
+func (w *World) Update(dt time.Duration) {
+	keep := math.Pow(1-w.Damping, dt.Seconds())
+	for _, p := range w.Points {
+		if p.Mass <= 0 || dt <= 0 {
+			continue
+		}
+		p.Prev = p.Pos.Minus(p.Pos.Minus(p.Prev).Times(keep))
+		p.accel.Add(w.Gravity)
+		p.Update(dt)
+	}
+
+	for i := 0; i < w.Iterations; i++ {
+		var torn []Constraint
+		kept := w.Constraints[:0]
+		for _, c := range w.Constraints {
+			if c.Solve() {
+				torn = append(torn, c)
+			} else {
+				kept = append(kept, c)
+			}
+		}
+		// Clear the end so torn constraints can be garbage collected.
+		for j := len(kept); j < len(w.Constraints); j++ {
+			w.Constraints[j] = nil
+		}
+		w.Constraints = kept
+		if w.OnTear != nil {
+			for _, c := range torn {
+				w.OnTear(c)
+			}
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package verlet
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Bredgren/gogame/geo"
+)
+
+const e = 1e-10
+
+const frame = time.Second / 60
+
+func run(w *World, d time.Duration) {
+	for t := time.Duration(0); t < d; t += frame {
+		w.Update(frame)
+	}
+}
+
+func TestPointUpdate(t *testing.T) {
+	p := NewPoint(geo.Vec{})
+	p.Prev = geo.Vec{X: -1}
+	p.Update(time.Second)
+	if want := (geo.Vec{X: 1}); !p.Pos.Equals(want, e) || !p.Vel().Equals(want, e) {
+		t.Errorf("got %#v moving %#v, want %#v", p.Pos, p.Vel(), want)
+	}
+
+	// Changing the time step keeps the velocity.
+	p.Update(time.Second / 2)
+	if want := (geo.Vec{X: 1.5}); !p.Pos.Equals(want, e) || !p.Vel().Equals(geo.Vec{X: 1}, e) {
+		t.Errorf("got %#v moving %#v, want %#v", p.Pos, p.Vel(), want)
+	}
+
+	// Pausing keeps the velocity.
+	p.Update(0)
+	if want := (geo.Vec{X: 1.5}); p.Pos != want || !p.Vel().Equals(geo.Vec{X: 1}, e) {
+		t.Errorf("got %#v moving %#v after pausing, want %#v", p.Pos, p.Vel(), want)
+	}
+
+	p.ApplyForce(geo.Vec{Y: 8})
+	p.Mass = 2
+	p.ApplyForce(geo.Vec{Y: 8})
+	p.Update(time.Second / 2)
+	if want := (geo.Vec{X: 2, Y: 3}); !p.Pos.Equals(want, e) {
+		t.Errorf("got %#v, want %#v", p.Pos, want)
+	}
+}
+
+func TestWorldPause(t *testing.T) {
+	w := NewWorld(geo.Vec{Y: 10})
+	p := NewPoint(geo.Vec{})
+	p.Prev = geo.Vec{X: -1}
+	w.Points = append(w.Points, p)
+	w.Update(time.Second)
+	w.Update(0)
+	if want := (geo.Vec{X: 1, Y: 10}); !p.Pos.Equals(want, e) || !p.Vel().Equals(want, e) {
+		t.Errorf("got %#v moving %#v, want %#v", p.Pos, p.Vel(), want)
+	}
+	w.Update(time.Second / 60)
+	if math.Abs(p.Vel().X-1) > e {
+		t.Errorf("got %#v after pausing", p.Vel())
+	}
+}
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		massA, massB float64
+		stiffness    float64
+		wantA, wantB geo.Vec
+	}{
+		{1, 1, 1, geo.Vec{X: 2}, geo.Vec{X: 8}},
+		{1, 1, 0.5, geo.Vec{X: 1}, geo.Vec{X: 9}},
+		{0, 1, 1, geo.Vec{}, geo.Vec{X: 6}},
+		{1, 3, 1, geo.Vec{X: 3}, geo.Vec{X: 9}},
+		{0, 0, 1, geo.Vec{}, geo.Vec{X: 10}},
+	}
+
+	for i, c := range cases {
+		a, b := NewPoint(geo.Vec{}), NewPoint(geo.Vec{X: 6})
+		d := NewDistance(a, b, c.stiffness)
+		a.Mass, b.Mass = c.massA, c.massB
+		b.Pos.X = 10
+		if d.Solve() {
+			t.Errorf("case %d: tore", i)
+		}
+		if !a.Pos.Equals(c.wantA, e) || !b.Pos.Equals(c.wantB, e) {
+			t.Errorf("case %d: got %#v, %#v, want %#v, %#v", i, a.Pos, b.Pos, c.wantA, c.wantB)
+		}
+	}
+
+	a, b := NewPoint(geo.Vec{}), NewPoint(geo.Vec{X: 6})
+	d := NewDistance(a, b, 1)
+	d.TearLength = 9
+	b.Pos.X = 9
+	if d.Solve() {
+		t.Errorf("tore at the limit")
+	}
+	b.Pos.X = a.Pos.X + 9.1
+	if !d.Solve() {
+		t.Errorf("didn't tear")
+	}
+}
+
+func TestAngle(t *testing.T) {
+	a, b, c := NewPoint(geo.Vec{X: 1}), NewPoint(geo.Vec{}), NewPoint(geo.Vec{Y: -1})
+	ang := NewAngle(a, b, c, 1)
+	if math.Abs(ang.Angle-math.Pi/2) > e {
+		t.Errorf("got angle %v, want π/2", ang.Angle)
+	}
+
+	// Closing the joint to 0 and letting it spring back open evenly.
+	c.Pos = geo.Vec{X: 1}
+	ang.Solve()
+	wantA, wantC := geo.Vec{X: 1}.Rotated(-math.Pi/4), geo.Vec{X: 1}.Rotated(math.Pi/4)
+	if !a.Pos.Equals(wantA, e) || !c.Pos.Equals(wantC, e) || b.Pos != (geo.Vec{}) {
+		t.Errorf("got %#v, %#v, %#v, want %#v, %#v", a.Pos, b.Pos, c.Pos, wantA, wantC)
+	}
+
+	// Only C moves when A is fixed.
+	a.Pos, c.Pos = geo.Vec{X: 1}, geo.Vec{X: -1}
+	a.Mass = 0
+	ang.Solve()
+	if want := (geo.Vec{Y: -1}); !c.Pos.Equals(want, e) || a.Pos != (geo.Vec{X: 1}) {
+		t.Errorf("got %#v, want %#v", c.Pos, want)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	p := NewPoint(geo.Vec{X: 5, Y: 12})
+	p.Prev = geo.Vec{X: 3, Y: 8}
+	fixed := NewPoint(geo.Vec{X: 20})
+	fixed.Mass = 0
+	b := &Bounds{Points: []*Point{p, fixed}, Rect: geo.Rect{W: 10, H: 10}, Bounce: 0.5}
+	b.Solve()
+	if want := (geo.Vec{X: 5, Y: 10}); p.Pos != want {
+		t.Errorf("got %#v, want %#v", p.Pos, want)
+	}
+	// It was moving down 4 and now moves up 2.
+	if want := (geo.Vec{X: 3, Y: 12}); p.Prev != want {
+		t.Errorf("got prev %#v, want %#v", p.Prev, want)
+	}
+	if fixed.Pos.X != 20 {
+		t.Errorf("moved fixed point")
+	}
+}
+
+func TestRope(t *testing.T) {
+	w := NewWorld(geo.Vec{Y: 500})
+	w.Damping = 0.9
+	rope := Rope(geo.Vec{X: 100, Y: 100}, geo.Vec{X: 200, Y: 100}, 10, 1)
+	w.Add(rope)
+	if len(rope.Points) != 11 || len(rope.Constraints) != 11 {
+		t.Errorf("got %d points and %d constraints", len(rope.Points), len(rope.Constraints))
+	}
+	run(w, 10*time.Second)
+
+	if want := (geo.Vec{X: 100, Y: 100}); rope.Points[0].Pos != want {
+		t.Errorf("top moved to %#v", rope.Points[0].Pos)
+	}
+	// It hangs straight down, stretching a little.
+	for i, p := range rope.Points {
+		want := geo.Vec{X: 100, Y: 100 + 10*float64(i)}
+		if !p.Pos.Equals(want, 1) {
+			t.Errorf("point %d: got %#v, want %#v", i, p.Pos, want)
+		}
+	}
+
+	// Dragging the pin drags the rope.
+	pin := rope.Constraints[len(rope.Constraints)-1].(*Pin)
+	pin.Pos.X = 300
+	run(w, 10*time.Second)
+	if end := rope.Points[10].Pos; math.Abs(end.X-300) > 1 {
+		t.Errorf("end at %#v", end)
+	}
+}
+
+func TestCloth(t *testing.T) {
+	w := NewWorld(geo.Vec{Y: 500})
+	cloth := Cloth(geo.Rect{X: 0, Y: 0, W: 100, H: 50}, 11, 6, 1)
+	w.Add(cloth)
+	if want := 10*6 + 11*5 + 11; len(cloth.Points) != 66 || len(cloth.Constraints) != want {
+		t.Errorf("got %d points and %d constraints, want 66 and %d", len(cloth.Points), len(cloth.Constraints), want)
+	}
+	if want := (geo.Vec{X: 30, Y: 20}); cloth.Points[2*11+3].Pos != want {
+		t.Errorf("got %#v, want %#v", cloth.Points[2*11+3].Pos, want)
+	}
+
+	torn := 0
+	w.OnTear = func(c Constraint) {
+		torn++
+		if d := c.(*Distance); d.A.Pos.Dist(d.B.Pos) <= d.TearLength {
+			t.Errorf("tore %#v early", d)
+		}
+	}
+	cloth.SetTear(3)
+	run(w, time.Second)
+	if torn != 0 {
+		t.Errorf("tore %d under gravity alone", torn)
+	}
+
+	// Yanking the bottom corner rips it off.
+	corner := cloth.Points[len(cloth.Points)-1]
+	corner.Pos.Add(geo.Vec{X: 100, Y: 100})
+	w.Update(frame)
+	if torn != 2 || len(w.Constraints) != len(cloth.Constraints)-2 {
+		t.Errorf("tore %d, %d constraints left", torn, len(w.Constraints))
+	}
+}
+
+func TestRing(t *testing.T) {
+	w := NewWorld(geo.Vec{Y: 500})
+	ring := Ring(geo.Vec{X: 50, Y: 20}, 10, 12, 0.1)
+	w.Add(ring)
+	w.Constraints = append(w.Constraints, &Bounds{Points: ring.Points, Rect: geo.Rect{W: 100, H: 100}})
+	if len(ring.Points) != 13 || len(ring.Constraints) != 24 {
+		t.Errorf("got %d points and %d constraints", len(ring.Points), len(ring.Constraints))
+	}
+	run(w, 5*time.Second)
+
+	// It has landed on the floor and squashed a bit, but not flat.
+	center := ring.Points[12].Pos
+	if center.Y < 85 || center.Y > 95 {
+		t.Errorf("center at %#v", center)
+	}
+	for i, p := range ring.Points[:12] {
+		if d := p.Pos.Dist(center); d < 5 || d > 15 {
+			t.Errorf("point %d is %v from the center", i, d)
+		}
+	}
+}